@@ -0,0 +1,222 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/Disneyjr/dcm/internal/i18n"
+	"github.com/Disneyjr/dcm/pkg/dcm"
+)
+
+// flagDef declara uma flag. Flags com Value vazio são booleanas; as demais exigem um valor,
+// descrito por Value no help (ex: "<n>").
+type flagDef struct {
+	Name     string
+	Short    string
+	Value    string   // Chave do catálogo de mensagens
+	Usage    string   // Chave do catálogo de mensagens
+	Multiple bool     // Pode ser repetida; os valores são acumulados
+	Values   []string // Valores aceitos, oferecidos pelo completion
+	Path     bool     // O valor é um caminho; o completion sugere arquivos
+}
+
+func (f flagDef) usage() string {
+	names := "    --" + f.Name
+	if f.Short != "" {
+		names = "-" + f.Short + ", --" + f.Name
+	}
+	if f.Value != "" {
+		names += " " + i18n.T(f.Value)
+	}
+	return names
+}
+
+// commandDef declara um subcomando: argumentos, flags e o handler. O help é gerado a partir daqui.
+type commandDef struct {
+	Name        string
+	Args        string // Chave do catálogo de mensagens (ex: "args.target" para "<alvo>")
+	Summary     string // Chave do catálogo de mensagens
+	MinArgs     int
+	MaxArgs     int // -1 = sem limite
+	Flags       []flagDef
+	Workspace   bool // Precisa carregar o workspace; sem ele, Run recebe um Manager nil
+	Passthrough bool // Aceita argumentos depois de "--", repassados sem interpretação (ex: o comando do exec)
+	Complete    argKind
+	Run         func(ctx context.Context, m *dcm.Manager, in *invocation) error
+}
+
+// args devolve os argumentos do comando no idioma atual, para a ajuda e as mensagens de erro.
+func (c *commandDef) args() string {
+	if c.Args == "" {
+		return ""
+	}
+	return i18n.T(c.Args)
+}
+
+// argKind indica ao completion o que sugerir para o argumento posicional de um comando.
+type argKind int
+
+const (
+	argNone    argKind = iota
+	argTarget          // Grupo, projeto ou projeto:serviço
+	argGroup           // Apenas grupos
+	argCommand         // Nome de um comando
+	argShell           // Shell suportado pelo completion
+	argService         // Spec projeto:serviço
+	argTask            // Projeto com tarefas e, em seguida, uma das suas tarefas
+)
+
+// invocation é o resultado do parse: argumentos posicionais e valores das flags do comando e globais.
+type invocation struct {
+	Command *commandDef
+	Args    []string
+	// Passthrough guarda tudo o que vem depois de "--", sem interpretação
+	Passthrough []string
+	values      map[string][]string
+	bools       map[string]bool
+}
+
+func (in *invocation) Bool(name string) bool {
+	return in.bools[name]
+}
+
+func (in *invocation) String(name string) string {
+	values := in.values[name]
+	if len(values) == 0 {
+		return ""
+	}
+	return values[len(values)-1]
+}
+
+func (in *invocation) Strings(name string) []string {
+	return in.values[name]
+}
+
+// Arg retorna o argumento posicional i ou vazio.
+func (in *invocation) Arg(i int) string {
+	if i < len(in.Args) {
+		return in.Args[i]
+	}
+	return ""
+}
+
+func findCommand(name string) *commandDef {
+	for i := range commandDefs {
+		if commandDefs[i].Name == name {
+			return &commandDefs[i]
+		}
+	}
+	return nil
+}
+
+func lookupFlag(flags []flagDef, name string, short bool) *flagDef {
+	for i := range flags {
+		if (!short && flags[i].Name == name) || (short && flags[i].Short != "" && flags[i].Short == name) {
+			return &flags[i]
+		}
+	}
+	return nil
+}
+
+// splitFlag separa "--nome=valor" em nome e valor. Flags curtas ("-x") não aceitam "=".
+func splitFlag(arg string) (name string, short bool, value string, hasValue bool) {
+	if !strings.HasPrefix(arg, "--") {
+		return strings.TrimPrefix(arg, "-"), true, "", false
+	}
+	name, value, hasValue = strings.Cut(strings.TrimPrefix(arg, "--"), "=")
+	return name, false, value, hasValue
+}
+
+// resolveFlag procura a flag entre as globais e as do comando (se já conhecido).
+func resolveFlag(cmd *commandDef, name string, short bool) *flagDef {
+	if def := lookupFlag(globalFlags, name, short); def != nil {
+		return def
+	}
+	if cmd != nil {
+		return lookupFlag(cmd.Flags, name, short)
+	}
+	return nil
+}
+
+// parseArgs separa o subcomando, suas flags e as flags globais, que podem aparecer em qualquer
+// posição. Flags desconhecidas são erro. Um comando vazio é retornado como nil.
+func parseArgs(args []string) (*invocation, error) {
+	in := &invocation{values: map[string][]string{}, bools: map[string]bool{}}
+	var positional []string
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		if arg == "--" {
+			in.Passthrough = args[i+1:]
+			break
+		}
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			if in.Command == nil {
+				in.Command = findCommand(arg)
+				if in.Command == nil {
+					return nil, i18n.Errorf("cli.unknown_command", arg)
+				}
+				continue
+			}
+			positional = append(positional, arg)
+			continue
+		}
+
+		name, short, value, hasValue := splitFlag(arg)
+		def := resolveFlag(in.Command, name, short)
+		if def == nil {
+			if name == "help" || (short && name == "h") {
+				in.bools["help"] = true
+				continue
+			}
+			if in.Command == nil {
+				return nil, i18n.Errorf("cli.unknown_flag", arg)
+			}
+			return nil, i18n.Errorf("cli.unknown_command_flag", in.Command.Name, arg, in.Command.Name)
+		}
+
+		if def.Value == "" {
+			enabled := true
+			if hasValue {
+				parsed, err := strconv.ParseBool(value)
+				if err != nil {
+					return nil, i18n.Errorf("cli.invalid_value", def.Name, value)
+				}
+				enabled = parsed
+			}
+			in.bools[def.Name] = enabled
+			continue
+		}
+
+		if !hasValue {
+			if i+1 >= len(args) {
+				return nil, i18n.Errorf("cli.missing_value", arg, i18n.T(def.Value))
+			}
+			i++
+			value = args[i]
+		}
+		if def.Multiple {
+			in.values[def.Name] = append(in.values[def.Name], value)
+		} else {
+			in.values[def.Name] = []string{value}
+		}
+	}
+
+	in.Args = positional
+	if in.Command == nil || in.bools["help"] {
+		return in, nil
+	}
+
+	if len(positional) < in.Command.MinArgs {
+		return nil, i18n.Errorf("cli.missing_args", in.Command.Name, in.Command.args())
+	}
+	if in.Command.MaxArgs >= 0 && len(positional) > in.Command.MaxArgs {
+		return nil, i18n.Errorf("cli.too_many_args", in.Command.Name, strings.Join(positional[in.Command.MaxArgs:], " "))
+	}
+	if len(in.Passthrough) > 0 && !in.Command.Passthrough {
+		return nil, i18n.Errorf("cli.unexpected_passthrough", in.Command.Name)
+	}
+	return in, nil
+}
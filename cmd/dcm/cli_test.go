@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseArgs(t *testing.T) {
+	in, err := parseArgs([]string{"--dry-run", "up", "dev", "--build", "-o", "json", "-f", "a.yaml", "--file=b.yaml"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if in.Command.Name != "up" || in.Arg(0) != "dev" {
+		t.Errorf("unexpected command/args: %s %v", in.Command.Name, in.Args)
+	}
+	if !in.Bool("dry-run") || !in.Bool("build") {
+		t.Error("expected dry-run and build to be set")
+	}
+	if in.String("output") != "json" {
+		t.Errorf("expected output json, got %q", in.String("output"))
+	}
+	if files := in.Strings("file"); !reflect.DeepEqual(files, []string{"a.yaml", "b.yaml"}) {
+		t.Errorf("unexpected files: %v", files)
+	}
+
+	// -v é a flag de volumes do down, e as globais valem depois do alvo
+	in, err = parseArgs([]string{"down", "dev", "-v", "--quiet"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !in.Bool("volumes") || !in.Bool("quiet") {
+		t.Error("expected volumes and quiet to be set")
+	}
+
+	// Depois de "--" nada é interpretado, nem as flags
+	in, err = parseArgs([]string{"exec", "api:web", "--dry-run", "--", "ls", "-la", "--help"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if in.Arg(0) != "api:web" || !in.Bool("dry-run") || in.Bool("help") || !reflect.DeepEqual(in.Passthrough, []string{"ls", "-la", "--help"}) {
+		t.Errorf("unexpected exec invocation: %v %v", in.Args, in.Passthrough)
+	}
+}
+
+func TestParseArgsErrors(t *testing.T) {
+	cases := [][]string{
+		{"up", "dev", "--bulid"}, // flag desconhecida
+		{"down", "--build"},      // flag de outro comando
+		{"up"},                   // alvo obrigatório
+		{"status", "a", "b"},     // argumentos demais
+		{"logs", "--tail"},       // flag sem valor
+		{"frobnicate"},           // comando desconhecido
+		{"up", "dev", "--build=talvez"},
+		{"up", "dev", "--", "x"}, // up não repassa argumentos
+	}
+	for _, args := range cases {
+		if _, err := parseArgs(args); err == nil {
+			t.Errorf("expected error for %v, got nil", args)
+		}
+	}
+}
+
+func TestCommandHelp(t *testing.T) {
+	in, err := parseArgs([]string{"logs", "--help"})
+	if err != nil || !in.Bool("help") {
+		t.Fatalf("expected --help to be accepted, got %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := writeCommandHelp(&out, "logs"); err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{"dcm logs [alvo]", "--since <t>", "--dry-run"} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("help is missing %q:\n%s", expected, out.String())
+		}
+	}
+
+	// Todo comando definido precisa aparecer na ajuda geral
+	out.Reset()
+	writeHelp(&out)
+	for _, cmd := range commandDefs {
+		if !strings.Contains(out.String(), "  "+cmd.Name) {
+			t.Errorf("general help is missing %s", cmd.Name)
+		}
+	}
+
+	if err := writeCommandHelp(&out, "nope"); err == nil {
+		t.Error("expected error for unknown command, got nil")
+	}
+}
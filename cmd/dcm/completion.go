@@ -0,0 +1,286 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Disneyjr/dcm/internal/i18n"
+	"github.com/Disneyjr/dcm/pkg/dcm"
+)
+
+// completeCommand é o comando oculto chamado pelos scripts de completion. Recebe as palavras já
+// digitadas depois de "dcm" e, por último, a palavra sendo completada (possivelmente vazia).
+const completeCommand = "__complete"
+
+var completionShells = []string{"bash", "zsh", "fish", "powershell"}
+
+const bashCompletion = `# bash completion para dcm
+# Instale com: source <(dcm completion bash)
+_dcm_completion() {
+    local cur words cword
+    if declare -F _get_comp_words_by_ref >/dev/null 2>&1; then
+        _get_comp_words_by_ref -n : cur words cword
+    else
+        cur="${COMP_WORDS[COMP_CWORD]}"
+        words=("${COMP_WORDS[@]}")
+        cword=$COMP_CWORD
+    fi
+
+    local IFS=$'\n'
+    COMPREPLY=($(dcm __complete "${words[@]:1:cword-1}" "$cur" 2>/dev/null))
+
+    # Specs "projeto:serviço": o bash trata ':' como separador de palavras
+    if declare -F __ltrim_colon_completions >/dev/null 2>&1; then
+        __ltrim_colon_completions "$cur"
+    fi
+    # Diretórios continuam sendo navegados, sem espaço no final
+    if [[ ${#COMPREPLY[@]} -eq 1 && ${COMPREPLY[0]} == */ ]]; then
+        compopt -o nospace 2>/dev/null
+    fi
+}
+complete -F _dcm_completion dcm
+`
+
+const zshCompletion = `#compdef dcm
+# zsh completion para dcm
+# Instale com: source <(dcm completion zsh)
+_dcm() {
+    local -a candidates
+    candidates=("${(@f)$(dcm __complete "${(@)words[2,CURRENT-1]}" "${words[CURRENT]}" 2>/dev/null)}")
+    candidates=(${candidates:#})
+    compadd -S '' -- ${(M)candidates:#*/}
+    compadd -- ${candidates:#*/}
+}
+compdef _dcm dcm
+`
+
+const fishCompletion = `# fish completion para dcm
+# Instale com: dcm completion fish > ~/.config/fish/completions/dcm.fish
+complete -c dcm -f -a '(dcm __complete (commandline -opc)[2..-1] (commandline -ct) 2>/dev/null)'
+`
+
+const powershellCompletion = `# PowerShell completion para dcm
+# Instale com: dcm completion powershell | Out-String | Invoke-Expression
+Register-ArgumentCompleter -Native -CommandName dcm -ScriptBlock {
+    param($wordToComplete, $commandAst, $cursorPosition)
+
+    $words = @($commandAst.CommandElements | Select-Object -Skip 1 | ForEach-Object { $_.ToString() })
+    if ($wordToComplete -ne '' -and $words.Count -gt 0) {
+        $words = @($words | Select-Object -SkipLast 1)
+    }
+
+    dcm __complete @words "$wordToComplete" 2>$null | ForEach-Object {
+        [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
+    }
+}
+`
+
+func handleCompletionCommand(ctx context.Context, m *dcm.Manager, in *invocation) error {
+	switch in.Arg(0) {
+	case "bash":
+		fmt.Print(bashCompletion)
+	case "zsh":
+		fmt.Print(zshCompletion)
+	case "fish":
+		fmt.Print(fishCompletion)
+	case "powershell":
+		fmt.Print(powershellCompletion)
+	default:
+		return i18n.Errorf("completion.unsupported_shell", in.Arg(0), strings.Join(completionShells, ", "))
+	}
+	return nil
+}
+
+// completeArgs calcula as sugestões para a última palavra de args. Erros (workspace inválido,
+// compose ilegível) apenas reduzem as sugestões: o completion nunca deve imprimir mensagens.
+func completeArgs(args []string) []string {
+	current := ""
+	if len(args) > 0 {
+		current = args[len(args)-1]
+		args = args[:len(args)-1]
+	}
+
+	var cmd *commandDef
+	var pending *flagDef
+	var positional []string
+	workspacePath := ""
+	var files []string
+
+	record := func(def *flagDef, value string) {
+		switch def.Name {
+		case "workspace":
+			workspacePath = value
+		case "file":
+			files = append(files, value)
+		}
+	}
+
+	for _, arg := range args {
+		if pending != nil {
+			record(pending, arg)
+			pending = nil
+			continue
+		}
+		if arg == "--" {
+			return nil
+		}
+		if strings.HasPrefix(arg, "-") && arg != "-" {
+			name, short, value, hasValue := splitFlag(arg)
+			def := resolveFlag(cmd, name, short)
+			if def == nil || def.Value == "" {
+				continue
+			}
+			if hasValue {
+				record(def, value)
+			} else {
+				pending = def
+			}
+			continue
+		}
+		if cmd == nil {
+			if cmd = findCommand(arg); cmd == nil {
+				return nil
+			}
+			continue
+		}
+		positional = append(positional, arg)
+	}
+
+	if pending != nil {
+		return completeFlagValue(pending, current, "")
+	}
+
+	if strings.HasPrefix(current, "--") && strings.Contains(current, "=") {
+		name, value, _ := strings.Cut(strings.TrimPrefix(current, "--"), "=")
+		def := resolveFlag(cmd, name, false)
+		if def == nil || def.Value == "" {
+			return nil
+		}
+		return completeFlagValue(def, value, "--"+name+"=")
+	}
+
+	if strings.HasPrefix(current, "-") {
+		flags := globalFlags
+		if cmd != nil {
+			flags = append(append([]flagDef{}, cmd.Flags...), globalFlags...)
+		}
+		names := make([]string, 0, len(flags)+1)
+		for _, f := range flags {
+			names = append(names, "--"+f.Name)
+		}
+		return filterPrefix(append(names, "--help"), current)
+	}
+
+	if cmd == nil {
+		return filterPrefix(commandNames(), current)
+	}
+	if cmd.MaxArgs >= 0 && len(positional) >= cmd.MaxArgs {
+		return nil
+	}
+
+	switch cmd.Complete {
+	case argTarget, argGroup, argService, argTask:
+		ws, err := dcm.LoadWorkspace(workspacePath, files...)
+		if err != nil {
+			return nil
+		}
+		m := dcm.New(ws)
+		switch cmd.Complete {
+		case argGroup:
+			return filterPrefix(m.Groups(), current)
+		case argService:
+			var specs []string
+			for _, target := range m.Targets() {
+				if strings.Contains(target, ":") {
+					specs = append(specs, target)
+				}
+			}
+			return filterPrefix(specs, current)
+		case argTask:
+			return filterPrefix(taskCandidates(m, positional), current)
+		}
+		return filterPrefix(m.Targets(), current)
+	case argCommand:
+		return filterPrefix(commandNames(), current)
+	case argShell:
+		return filterPrefix(completionShells, current)
+	}
+	return nil
+}
+
+// taskCandidates sugere os projetos que têm tarefas e, depois do projeto, as tarefas dele.
+func taskCandidates(m *dcm.Manager, positional []string) []string {
+	project := ""
+	if len(positional) > 0 {
+		project = positional[0]
+	}
+	result, err := m.Tasks(project)
+	if err != nil {
+		return nil
+	}
+
+	var candidates []string
+	for _, task := range result.Tasks {
+		candidate := task.Project
+		if project != "" {
+			candidate = task.Name
+		}
+		if !slices.Contains(candidates, candidate) {
+			candidates = append(candidates, candidate)
+		}
+	}
+	return candidates
+}
+
+func completeFlagValue(def *flagDef, current, prefix string) []string {
+	var candidates []string
+	switch {
+	case len(def.Values) > 0:
+		candidates = filterPrefix(def.Values, current)
+	case def.Path:
+		candidates = completePaths(current)
+	}
+
+	for i := range candidates {
+		candidates[i] = prefix + candidates[i]
+	}
+	return candidates
+}
+
+// completePaths sugere arquivos e diretórios que começam com current; diretórios terminam em "/"
+// para que o usuário possa continuar navegando.
+func completePaths(current string) []string {
+	matches, err := filepath.Glob(current + "*")
+	if err != nil {
+		return nil
+	}
+
+	for i, match := range matches {
+		if info, err := os.Stat(match); err == nil && info.IsDir() {
+			matches[i] = match + string(filepath.Separator)
+		}
+	}
+	return matches
+}
+
+func commandNames() []string {
+	names := make([]string, len(commandDefs))
+	for i, c := range commandDefs {
+		names[i] = c.Name
+	}
+	return names
+}
+
+func filterPrefix(values []string, prefix string) []string {
+	var filtered []string
+	for _, v := range values {
+		if strings.HasPrefix(v, prefix) {
+			filtered = append(filtered, v)
+		}
+	}
+	return filtered
+}
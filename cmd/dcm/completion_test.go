@@ -0,0 +1,56 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCompleteArgs(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "api"), 0755)
+	os.WriteFile(filepath.Join(dir, "api", "compose.yaml"), []byte("services:\n  web: {}\n  worker: {}\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "workspace.json"), []byte(`{
+  "projects": { "api": { "path": "./api", "tasks": { "migrate": { "service": "web", "command": ["migrate"] } } } },
+  "groups": { "dev": { "services": ["api"] } }
+}`), 0644)
+	t.Chdir(dir)
+
+	cases := []struct {
+		args     []string
+		expected []string
+	}{
+		{[]string{"re"}, []string{"restart"}},
+		{[]string{"up", ""}, []string{"dev", "api", "api:web", "api:worker"}},
+		{[]string{"up", "api:w"}, []string{"api:web", "api:worker"}},
+		{[]string{"--dry-run", "down", "-v", "d"}, []string{"dev"}},
+		{[]string{"inspect", ""}, []string{"dev"}},
+		{[]string{"up", "dev", ""}, nil}, // up aceita um único alvo
+		{[]string{"down", "--vo"}, []string{"--volumes"}},
+		{[]string{"status", "-o", "j"}, []string{"json"}},
+		{[]string{"init", "--format=y"}, []string{"--format=yaml"}},
+		{[]string{"completion", "f"}, []string{"fish"}},
+		{[]string{"exec", ""}, []string{"api:web", "api:worker"}},
+		{[]string{"task", ""}, []string{"api"}},
+		{[]string{"task", "api", "m"}, []string{"migrate"}},
+		{[]string{"exec", "api:web", "--", ""}, nil},
+		{[]string{"help", "comp"}, []string{"completion"}},
+		{[]string{"-w", "work"}, []string{"workspace.json"}},
+	}
+
+	for _, c := range cases {
+		if got := completeArgs(c.args); !reflect.DeepEqual(got, c.expected) {
+			t.Errorf("completeArgs(%q) = %q, expected %q", c.args, got, c.expected)
+		}
+	}
+
+	// Com --workspace, as sugestões vêm do workspace indicado e não do diretório atual
+	t.Chdir(t.TempDir())
+	if got := completeArgs([]string{"up", "--workspace", dir, "d"}); !reflect.DeepEqual(got, []string{"dev"}) {
+		t.Errorf("expected workspace from --workspace, got %q", got)
+	}
+	if got := completeArgs([]string{"up", ""}); got != nil {
+		t.Errorf("expected no suggestions without workspace, got %q", got)
+	}
+}
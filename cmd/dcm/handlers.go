@@ -1,89 +1,145 @@
 package main
 
 import (
-	"fmt"
-	"strings"
+	"context"
+	"os"
+	"strconv"
 
 	"github.com/Disneyjr/dcm/internal/commands"
+	"github.com/Disneyjr/dcm/internal/i18n"
+	"github.com/Disneyjr/dcm/internal/ui"
 	"github.com/Disneyjr/dcm/internal/workspace"
+	"github.com/Disneyjr/dcm/pkg/dcm"
 	"github.com/Disneyjr/dcm/utils/messages"
 )
 
-func handleUpCommand(ws *workspace.Workspace, args []string) error {
-	if len(args) < 2 {
-		return fmt.Errorf("especifique um projeto ou grupo")
-	}
+// Os handlers de comandos de workspace usam o Manager de pkg/dcm; o resultado tipado das
+// operações não é usado aqui, pois o progresso já foi impresso.
 
-	projectOrGroup := args[1]
-	extraArgs := []string{}
-	for i := 2; i < len(args); i++ {
-		if args[i] == "--build" {
-			extraArgs = append(extraArgs, "--build")
-		}
-		if args[i] == "--dry-run" {
-			commands.DryRun = true
+func handleUpCommand(ctx context.Context, m *dcm.Manager, in *invocation) error {
+	_, err := m.Up(ctx, in.Arg(0), dcm.UpOptions{Build: in.Bool("build")})
+	return err
+}
+
+func handleDownCommand(ctx context.Context, m *dcm.Manager, in *invocation) error {
+	opts := dcm.DownOptions{RemoveVolumes: in.Bool("volumes")}
+
+	if in.Bool("last") {
+		if in.Arg(0) != "" {
+			return usageError{i18n.Errorf("cli.last_with_target")}
 		}
+		_, err := m.DownLast(ctx, opts)
+		return err
 	}
 
-	return commands.UpGroup(ws, projectOrGroup, extraArgs...)
+	// Sem alvo, para o workspace inteiro
+	_, err := m.Down(ctx, in.Arg(0), opts)
+	return err
 }
 
-func handleDownCommand(ws *workspace.Workspace, args []string) error {
-	removeVolumes := false
-	groupName := ""
+func handleRestartCommand(ctx context.Context, m *dcm.Manager, in *invocation) error {
+	_, err := m.Restart(ctx, in.Arg(0))
+	return err
+}
 
-	// Parse arguments
-	for i := 1; i < len(args); i++ {
-		if args[i] == "-v" {
-			removeVolumes = true
-		} else if !strings.HasPrefix(args[i], "-") {
-			groupName = args[i]
-		}
+func handlePullCommand(ctx context.Context, m *dcm.Manager, in *invocation) error {
+	_, err := m.Pull(ctx, in.Arg(0))
+	return err
+}
+
+func handleBuildCommand(ctx context.Context, m *dcm.Manager, in *invocation) error {
+	opts := dcm.BuildOptions{NoCache: in.Bool("no-cache"), Pull: in.Bool("pull")}
+	_, err := m.Build(ctx, in.Arg(0), opts)
+	return err
+}
+
+func handleLogsCommand(ctx context.Context, m *dcm.Manager, in *invocation) error {
+	opts := dcm.LogsOptions{
+		Follow: in.Bool("follow"),
+		Since:  in.String("since"),
+		Tail:   in.String("tail"),
+		Grep:   in.String("grep"),
+	}
+	return m.Logs(ctx, in.Arg(0), opts, os.Stdout)
+}
+
+func handleStatusCommand(ctx context.Context, m *dcm.Manager, in *invocation) error {
+	return m.PrintStatus(ctx, in.Arg(0))
+}
+
+// execOptions liga os comandos avulsos ao terminal do dcm: stdin, TTY e saída vão direto ao compose.
+var execOptions = dcm.ExecOptions{Stdin: os.Stdin, Stdout: os.Stdout, Stderr: os.Stderr}
+
+func handleExecCommand(ctx context.Context, m *dcm.Manager, in *invocation) error {
+	if len(in.Passthrough) == 0 {
+		return usageError{i18n.Errorf("exec.missing_command")}
 	}
+	return m.Exec(ctx, in.Arg(0), in.Passthrough, execOptions)
+}
 
-	// If group is specified, use DownGroup
-	if groupName != "" {
-		return commands.DownGroup(ws, groupName, removeVolumes)
+func handleRunCommand(ctx context.Context, m *dcm.Manager, in *invocation) error {
+	if len(in.Passthrough) == 0 {
+		return usageError{i18n.Errorf("exec.missing_command")}
 	}
+	return m.Run(ctx, in.Arg(0), in.Passthrough, execOptions)
+}
 
-	// Otherwise, use DownAll
-	return commands.DownAll(ws, removeVolumes)
+// handleTaskCommand lista as tarefas (de todos os projetos ou de um) ou, com projeto e tarefa,
+// executa a tarefa com os argumentos depois de "--".
+func handleTaskCommand(ctx context.Context, m *dcm.Manager, in *invocation) error {
+	if len(in.Args) < 2 {
+		if len(in.Passthrough) > 0 {
+			return usageError{i18n.Errorf("cli.missing_args", in.Command.Name, in.Command.args())}
+		}
+		return m.PrintTasks(in.Arg(0))
+	}
+	return m.RunTask(ctx, in.Arg(0), in.Arg(1), in.Passthrough, execOptions)
 }
 
-func handleRestartCommand(ws *workspace.Workspace) error {
-	return commands.RestartAll(ws)
+func handleHistoryCommand(ctx context.Context, m *dcm.Manager, in *invocation) error {
+	limit := 20
+	if value := in.String("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return usageError{i18n.Errorf("cli.invalid_value", "limit", value)}
+		}
+		limit = n
+	}
+	return m.PrintHistory(limit)
 }
 
-func handleLogsCommand(ws *workspace.Workspace) error {
-	return commands.LogsAll(ws)
+func handleUICommand(ctx context.Context, m *dcm.Manager, in *invocation) error {
+	return ui.Run(ctx, m)
 }
 
-func handleStatusCommand(ws *workspace.Workspace) error {
-	return commands.StatusAll(ws)
+func handleListCommand(ctx context.Context, m *dcm.Manager, in *invocation) error {
+	return m.PrintList()
 }
 
-func handleListCommand(ws *workspace.Workspace) error {
-	commands.ListAll(ws)
-	return nil
+func handleInspectCommand(ctx context.Context, m *dcm.Manager, in *invocation) error {
+	return m.PrintInspect(in.Arg(0))
 }
 
-func handleInspectCommand(ws *workspace.Workspace, args []string) error {
-	if len(args) < 2 {
-		return fmt.Errorf("especifique um grupo para inspecionar")
-	}
-	commands.InspectGroup(ws, args[1])
-	return nil
+func handleValidateCommand(ctx context.Context, m *dcm.Manager, in *invocation) error {
+	return m.Validate()
 }
 
-func handleValidateCommand(ws *workspace.Workspace) error {
-	commands.ValidateWorkspace(ws)
-	return nil
+func handleConfigCommand(ctx context.Context, m *dcm.Manager, in *invocation) error {
+	if in.Bool("sources") {
+		return m.PrintConfigSources()
+	}
+	return m.PrintConfig()
 }
 
-func handleInitCommand() error {
-	return commands.InitWorkspace()
+func handleInitCommand(ctx context.Context, m *dcm.Manager, in *invocation) error {
+	format := workspace.FormatJSON
+	if value := in.String("format"); value != "" {
+		format = value
+	}
+	return commands.InitWorkspace(format)
 }
 
-func handleVersionCommand() {
+func handleVersionCommand(ctx context.Context, m *dcm.Manager, in *invocation) error {
 	messages.VersionMessage()
+	return nil
 }
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Disneyjr/dcm/internal/i18n"
+	"github.com/Disneyjr/dcm/utils"
+	"github.com/Disneyjr/dcm/utils/messages"
+)
+
+// A ajuda é gerada a partir de commandDefs e globalFlags, as mesmas definições usadas pelo parser.
+
+func printHelp() {
+	writeHelp(os.Stdout)
+}
+
+func writeHelp(out io.Writer) {
+	fmt.Fprintf(out, "%sDCM - Docker Compose Manager\n\n", utils.Icon("cyan", "📌"))
+	fmt.Fprintf(out, "%s\n\n", i18n.T("help.version", messages.Version))
+	fmt.Fprintln(out, i18n.T("help.usage_title"))
+	fmt.Fprintf(out, "  dcm %s\n", i18n.T("help.usage_line"))
+	fmt.Fprintln(out)
+
+	fmt.Fprintln(out, i18n.T("help.commands"))
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	for _, cmd := range commandDefs {
+		fmt.Fprintf(w, "  %s\t%s\n", strings.TrimSpace(cmd.Name+" "+cmd.args()), i18n.T(cmd.Summary))
+	}
+	w.Flush()
+	fmt.Fprintln(out)
+
+	fmt.Fprintln(out, i18n.T("help.global_options"))
+	writeFlags(out, globalFlags)
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, i18n.T("help.command_hint"))
+}
+
+func printCommandHelp(name string) error {
+	return writeCommandHelp(os.Stdout, name)
+}
+
+func writeCommandHelp(out io.Writer, name string) error {
+	cmd := findCommand(name)
+	if cmd == nil {
+		return i18n.Errorf("cli.unknown_command", name)
+	}
+
+	usage := "dcm " + cmd.Name
+	if cmd.Args != "" {
+		usage += " " + cmd.args()
+	}
+	if len(cmd.Flags) > 0 {
+		usage += " " + i18n.T("help.options_placeholder")
+	}
+	fmt.Fprintf(out, "%s\n\n", i18n.T("help.usage", usage))
+	fmt.Fprintf(out, "%s\n", i18n.T(cmd.Summary))
+
+	if len(cmd.Flags) > 0 {
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, i18n.T("help.options"))
+		writeFlags(out, cmd.Flags)
+	}
+
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, i18n.T("help.global_options"))
+	writeFlags(out, globalFlags)
+	return nil
+}
+
+func writeFlags(out io.Writer, flags []flagDef) {
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	for _, f := range flags {
+		fmt.Fprintf(w, "  %s\t%s\n", f.usage(), i18n.T(f.Usage))
+	}
+	w.Flush()
+}
@@ -1,72 +1,394 @@
 package main
 
 import (
-	"flag"
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
 
-	"github.com/Disneyjr/dcm/internal/workspace"
+	"github.com/Disneyjr/dcm/internal/commands"
+	"github.com/Disneyjr/dcm/internal/i18n"
+	"github.com/Disneyjr/dcm/pkg/dcm"
 	"github.com/Disneyjr/dcm/utils"
 	"github.com/Disneyjr/dcm/utils/messages"
+	"github.com/Disneyjr/dcm/utils/terminal"
 )
 
+// globalFlags valem para todos os comandos e podem aparecer em qualquer posição.
+var globalFlags = []flagDef{
+	{Name: "dry-run", Usage: "flag.dry-run"},
+	{Name: "workspace", Short: "w", Value: "args.file_or_dir", Usage: "flag.workspace", Path: true},
+	{Name: "file", Short: "f", Value: "args.file", Usage: "flag.file", Multiple: true, Path: true},
+	{Name: "output", Short: "o", Value: "args.output_format", Usage: "flag.output", Values: []string{"text", "json", "yaml"}},
+	{Name: "jobs", Short: "j", Value: "args.n", Usage: "flag.jobs"},
+	{Name: "verbose", Usage: "flag.verbose"},
+	{Name: "quiet", Short: "q", Usage: "flag.quiet"},
+	{Name: "save-logs", Usage: "flag.save-logs"},
+	{Name: "lang", Value: "args.language", Usage: "flag.lang", Values: i18n.Locales()},
+	{Name: "color", Value: "args.color_mode", Usage: "flag.color", Values: terminal.ColorModes},
+	{Name: "plain", Usage: "flag.plain"},
+	{Name: "progress", Value: "args.progress_mode", Usage: "flag.progress", Values: progressModes},
+}
+
+// Modos da flag --progress.
+const (
+	progressAuto = "auto" // Ao vivo em terminais; texto em pipes, arquivos e com --quiet
+	progressText = "text"
+	progressLive = "live"
+	progressJSON = "json"
+)
+
+var progressModes = []string{progressAuto, progressText, progressLive, progressJSON}
+
+var commandDefs = []commandDef{
+	{
+		Name: "up", Args: "args.target", Summary: "cmd.up.summary",
+		MinArgs: 1, MaxArgs: 1, Workspace: true, Complete: argTarget,
+		Flags: []flagDef{
+			{Name: "build", Usage: "flag.build"},
+			{Name: "fail-fast", Usage: "flag.fail-fast"},
+		},
+		Run: handleUpCommand,
+	},
+	{
+		Name: "down", Args: "args.optional_target", Summary: "cmd.down.summary",
+		MaxArgs: 1, Workspace: true, Complete: argTarget,
+		Flags: []flagDef{
+			{Name: "volumes", Short: "v", Usage: "flag.volumes"},
+			{Name: "last", Usage: "flag.last"},
+		},
+		Run: handleDownCommand,
+	},
+	{
+		Name: "restart", Args: "args.optional_target", Summary: "cmd.restart.summary",
+		MaxArgs: 1, Workspace: true, Complete: argTarget, Run: handleRestartCommand,
+	},
+	{
+		Name: "pull", Args: "args.optional_target", Summary: "cmd.pull.summary",
+		MaxArgs: 1, Workspace: true, Complete: argTarget, Run: handlePullCommand,
+	},
+	{
+		Name: "build", Args: "args.optional_target", Summary: "cmd.build.summary",
+		MaxArgs: 1, Workspace: true, Complete: argTarget,
+		Flags: []flagDef{
+			{Name: "no-cache", Usage: "flag.no-cache"},
+			{Name: "pull", Usage: "flag.pull"},
+		},
+		Run: handleBuildCommand,
+	},
+	{
+		Name: "logs", Args: "args.optional_target", Summary: "cmd.logs.summary",
+		MaxArgs: 1, Workspace: true, Complete: argTarget,
+		Flags: []flagDef{
+			{Name: "follow", Usage: "flag.follow"},
+			{Name: "since", Value: "args.time", Usage: "flag.since"},
+			{Name: "tail", Value: "args.n", Usage: "flag.tail"},
+			{Name: "grep", Value: "args.regex", Usage: "flag.grep"},
+		},
+		Run: handleLogsCommand,
+	},
+	{
+		Name: "status", Args: "args.optional_target", Summary: "cmd.status.summary",
+		MaxArgs: 1, Workspace: true, Complete: argTarget, Run: handleStatusCommand,
+	},
+	{
+		Name: "exec", Args: "args.exec", Summary: "cmd.exec.summary",
+		MinArgs: 1, MaxArgs: 1, Workspace: true, Passthrough: true, Complete: argService, Run: handleExecCommand,
+	},
+	{
+		Name: "run", Args: "args.exec", Summary: "cmd.run.summary",
+		MinArgs: 1, MaxArgs: 1, Workspace: true, Passthrough: true, Complete: argService, Run: handleRunCommand,
+	},
+	{
+		Name: "task", Args: "args.task", Summary: "cmd.task.summary",
+		MaxArgs: 2, Workspace: true, Passthrough: true, Complete: argTask, Run: handleTaskCommand,
+	},
+	{
+		Name: "history", Summary: "cmd.history.summary",
+		Workspace: true,
+		Flags: []flagDef{
+			{Name: "limit", Short: "n", Value: "args.n", Usage: "flag.limit"},
+		},
+		Run: handleHistoryCommand,
+	},
+	{
+		Name: "ui", Summary: "cmd.ui.summary",
+		Workspace: true, Run: handleUICommand,
+	},
+	{
+		Name: "list", Summary: "cmd.list.summary",
+		Workspace: true, Run: handleListCommand,
+	},
+	{
+		Name: "inspect", Args: "args.group", Summary: "cmd.inspect.summary",
+		MinArgs: 1, MaxArgs: 1, Workspace: true, Complete: argGroup, Run: handleInspectCommand,
+	},
+	{
+		Name: "config", Summary: "cmd.config.summary",
+		Workspace: true,
+		Flags: []flagDef{
+			{Name: "sources", Usage: "flag.sources"},
+		},
+		Run: handleConfigCommand,
+	},
+	{
+		Name: "validate", Summary: "cmd.validate.summary",
+		Workspace: true, Run: handleValidateCommand,
+	},
+	{
+		Name: "init", Summary: "cmd.init.summary",
+		Flags: []flagDef{
+			{Name: "format", Value: "args.init_format", Usage: "flag.format", Values: []string{"json", "yaml", "toml"}},
+		},
+		Run: handleInitCommand,
+	},
+	{
+		Name: "version", Summary: "cmd.version.summary",
+		Run: handleVersionCommand,
+	},
+	{
+		Name: "completion", Args: "args.shell", Summary: "cmd.completion.summary",
+		MinArgs: 1, MaxArgs: 1, Complete: argShell, Run: handleCompletionCommand,
+	},
+	{
+		// Run é tratado em runDcm: o help precisa de commandDefs, o que criaria um ciclo de inicialização
+		Name: "help", Args: "args.optional_command", Summary: "cmd.help.summary",
+		MaxArgs: 1, Complete: argCommand,
+	},
+}
+
 func main() {
-	flag.Parse()
-	args := flag.Args()
+	i18n.SetLocale(i18n.DetectLocale())
+	terminal.Setup(terminal.ColorAuto, false)
 
-	if len(args) == 0 {
-		messages.PrintHelp()
+	if len(os.Args) < 2 {
+		printHelp()
 		return
 	}
 
-	if err := runDcm(args); err != nil {
-		fmt.Printf("%s %v\n", utils.Colorize("red", "❌"), err)
-		messages.ExitMessage()
+	// Chamado pelos scripts de completion a cada <TAB>; nunca deve falhar nem pausar
+	if os.Args[1] == completeCommand {
+		for _, candidate := range completeArgs(os.Args[2:]) {
+			fmt.Println(candidate)
+		}
+		return
+	}
+
+	in, err := parseArgs(os.Args[1:])
+	if err != nil {
+		exitWithError(usageError{err})
+	}
+
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	if err := runDcm(ctx, in); err != nil {
+		cancel()
+		// exec, run e task saem com o código do comando, que já mostrou os próprios erros
+		var exitErr *dcm.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
+		exitWithError(err)
 	}
 }
 
-func runDcm(args []string) error {
-	var ws *workspace.Workspace
-	// Comandos que não precisam de workspace
-	if args[0] != "version" && args[0] != "init" {
-		ws = workspace.NewWorkspace()
-		if err := workspace.LoadWorkspace(ws); err != nil {
-			return err
+// signalContext é cancelado no primeiro Ctrl+C (ou SIGTERM): as operações em andamento repassam a
+// interrupção aos processos do compose e esperam que eles encerrem. Depois disso o sinal volta ao
+// comportamento padrão, então um segundo Ctrl+C encerra o dcm na hora.
+func signalContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		defer signal.Stop(signals)
+		select {
+		case <-signals:
+			fmt.Fprintf(os.Stderr, "\n%s%s\n", utils.Icon("yellow", "⏹️"), i18n.T("cli.interrupting"))
+			cancel()
+		case <-ctx.Done():
 		}
+	}()
+	return ctx, cancel
+}
+
+// Códigos de saída, para que scripts e CI identifiquem a causa da falha sem ler a saída.
+const (
+	exitError             = 1 // Falha sem classe específica
+	exitUsage             = 2 // Comando, flag ou argumento inválido
+	exitWorkspaceNotFound = 3
+	exitInvalidConfig     = 4
+	exitComposeFailed     = 5
+	exitPartialFailure    = 6   // Parte dos serviços falhou e parte deu certo
+	exitInterrupted       = 130 // Convenção dos shells para término por SIGINT
+)
+
+// usageError marca erros na linha de comando em si.
+type usageError struct{ error }
+
+func (e usageError) Unwrap() error { return e.error }
+
+func exitCode(err error) int {
+	var usage usageError
+	switch {
+	case errors.As(err, &usage):
+		return exitUsage
+	case errors.Is(err, context.Canceled):
+		return exitInterrupted
+	case errors.Is(err, dcm.ErrWorkspaceNotFound):
+		return exitWorkspaceNotFound
+	case errors.Is(err, dcm.ErrInvalidConfig):
+		return exitInvalidConfig
+	case errors.Is(err, dcm.ErrPartialFailure):
+		return exitPartialFailure
+	case errors.Is(err, dcm.ErrComposeFailed):
+		return exitComposeFailed
 	}
+	return exitError
+}
 
-	switch args[0] {
-	case "version":
-		handleVersionCommand()
-		return nil
+func exitWithError(err error) {
+	fmt.Fprintf(os.Stderr, "%s%v\n", utils.Icon("red", "❌"), err)
+	messages.ExitMessage()
+	os.Exit(exitCode(err))
+}
 
-	case "init":
-		return handleInitCommand()
+func runDcm(ctx context.Context, in *invocation) error {
+	// Idioma e terminal vêm antes de tudo, para valer também para a ajuda
+	if err := applyOutputFlags(in); err != nil {
+		return usageError{err}
+	}
 
-	case "validate":
-		return handleValidateCommand(ws)
+	if in.Command == nil {
+		printHelp()
+		return nil
+	}
+	if in.Bool("help") {
+		return printCommandHelp(in.Command.Name)
+	}
+	if in.Command.Name == "help" {
+		if in.Arg(0) == "" {
+			printHelp()
+			return nil
+		}
+		if err := printCommandHelp(in.Arg(0)); err != nil {
+			return usageError{err}
+		}
+		return nil
+	}
 
-	case "up":
-		return handleUpCommand(ws, args)
+	opts, err := managerOptions(in)
+	if err != nil {
+		return usageError{err}
+	}
 
-	case "down":
-		return handleDownCommand(ws, args)
+	// O engine do compose é procurado pelo Manager só quando um comando o executa
+	var m *dcm.Manager
+	if in.Command.Workspace {
+		ws, err := dcm.LoadWorkspace(in.String("workspace"), in.Strings("file")...)
+		if err != nil {
+			return err
+		}
+		if in.Bool("save-logs") {
+			opts = append(opts, dcm.WithLogDir(filepath.Join(ws.BaseDir, ".dcm", "logs", time.Now().Format("20060102-150405"))))
+		}
+		m = dcm.New(ws, opts...)
+	}
 
-	case "restart":
-		return handleRestartCommand(ws)
+	return in.Command.Run(ctx, m, in)
+}
 
-	case "logs":
-		return handleLogsCommand(ws)
+// applyOutputFlags aplica as flags que mudam a forma da saída: idioma, cores e emojis.
+func applyOutputFlags(in *invocation) error {
+	if lang := in.String("lang"); lang != "" {
+		if err := i18n.SetLocale(lang); err != nil {
+			return err
+		}
+	}
+	return terminal.Setup(in.String("color"), in.Bool("plain"))
+}
 
-	case "status":
-		return handleStatusCommand(ws)
+// managerOptions converte as flags globais (e o --fail-fast do up) em opções do Manager.
+func managerOptions(in *invocation) ([]dcm.Option, error) {
+	if in.Bool("verbose") && in.Bool("quiet") {
+		return nil, i18n.Errorf("cli.verbose_quiet")
+	}
+	opts := []dcm.Option{
+		dcm.WithDryRun(in.Bool("dry-run")),
+		dcm.WithVerbose(in.Bool("verbose")),
+		dcm.WithQuiet(in.Bool("quiet")),
+		dcm.WithFailFast(in.Bool("fail-fast")),
+	}
 
-	case "list":
-		return handleListCommand(ws)
+	if value := in.String("jobs"); value != "" {
+		jobs, err := strconv.Atoi(value)
+		if err != nil || jobs < 1 {
+			return nil, i18n.Errorf("cli.invalid_jobs", value)
+		}
+		opts = append(opts, dcm.WithConcurrency(jobs))
+	}
 
-	case "inspect":
-		return handleInspectCommand(ws, args)
+	if value := in.String("output"); value != "" {
+		format, err := commands.ParseOutputFormat(value)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, dcm.WithOutputFormat(format))
+	}
+
+	progress, err := progressOptions(in.String("progress"), in.Bool("quiet"))
+	if err != nil {
+		return nil, err
+	}
+	return append(opts, progress...), nil
+}
 
+// progressOptions escolhe como o progresso das operações é mostrado. Fora do modo json, uma tabela
+// com a duração de cada serviço fecha a operação, a menos que quiet esteja ativo.
+func progressOptions(mode string, quiet bool) ([]dcm.Option, error) {
+	switch mode {
+	case "", progressAuto:
+		mode = progressText
+		if terminal.Interactive() && !quiet {
+			mode = progressLive
+		}
+	case progressText, progressLive, progressJSON:
 	default:
-		return fmt.Errorf("comando desconhecido: %s", args[0])
+		return nil, i18n.Errorf("cli.invalid_progress", mode, strings.Join(progressModes, ", "))
+	}
+
+	summary := func(out io.Writer) dcm.Reporter {
+		if quiet {
+			return nil
+		}
+		return dcm.NewSummaryReporter(out)
+	}
+
+	switch mode {
+	case progressJSON:
+		// A saída padrão fica só com os eventos e os documentos de -o json|yaml; a do compose e as
+		// demais mensagens vão para o stderr
+		return []dcm.Option{
+			dcm.WithOutput(os.Stderr),
+			dcm.WithDataOutput(os.Stdout),
+			dcm.WithReporter(dcm.NewJSONReporter(os.Stdout)),
+		}, nil
+	case progressLive:
+		// O stderr continua separado (ex: 2>erros.log), mas também passa pelas linhas ao vivo
+		live := dcm.NewLiveReporter(os.Stdout)
+		return []dcm.Option{
+			dcm.WithOutput(live),
+			dcm.WithErrorOutput(live.Wrap(os.Stderr)),
+			dcm.WithReporter(dcm.MultiReporter(live, summary(live))),
+		}, nil
 	}
+	return []dcm.Option{dcm.WithReporter(dcm.MultiReporter(dcm.NewConsoleReporter(os.Stdout, quiet), summary(os.Stdout)))}, nil
 }
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Disneyjr/dcm/internal/commands"
+)
+
+func TestExitCode(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "workspace.json"), []byte(`{"projects": {"api": {"path": "./missing"}}}`), 0644)
+
+	cases := []struct {
+		args     []string
+		expected int
+	}{
+		{[]string{"list", "--jobs", "0"}, exitUsage},
+		{[]string{"help", "nada"}, exitUsage},
+		{[]string{"-w", filepath.Join(dir, "nada"), "list"}, exitWorkspaceNotFound},
+		{[]string{"-w", dir, "validate"}, exitInvalidConfig},
+	}
+	for _, c := range cases {
+		in, err := parseArgs(c.args)
+		if err != nil {
+			t.Fatalf("%v: unexpected parse error: %v", c.args, err)
+		}
+		var runErr error
+		withStdout(t, func() { runErr = runDcm(context.Background(), in) })
+		if code := exitCode(runErr); code != c.expected {
+			t.Errorf("%v: expected exit code %d, got %d (%v)", c.args, c.expected, code, runErr)
+		}
+	}
+
+	if code := exitCode(usageError{fmt.Errorf("flag desconhecida")}); code != exitUsage {
+		t.Errorf("expected usage exit code, got %d", code)
+	}
+	if code := exitCode(fmt.Errorf("envolvido: %w", commands.ErrPartialFailure)); code != exitPartialFailure {
+		t.Errorf("expected partial failure exit code, got %d", code)
+	}
+	if code := exitCode(fmt.Errorf("operação interrompida: %w", context.Canceled)); code != exitInterrupted {
+		t.Errorf("expected interrupted exit code, got %d", code)
+	}
+	if code := exitCode(fmt.Errorf("outro")); code != exitError {
+		t.Errorf("expected generic exit code, got %d", code)
+	}
+}
+
+func TestProgressJSONWithStructuredOutput(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "workspace.json"), []byte(`{"projects": {"api": {"path": "."}}}`), 0644)
+
+	// Os eventos vão para o stdout, e o documento do -o json também precisa continuar lá
+	in, err := parseArgs([]string{"-w", dir, "--progress", "json", "-o", "json", "list"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout, stderr := captureOutput(t, func() {
+		if err := runDcm(context.Background(), in); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	var result struct {
+		Projects []struct {
+			Name string `json:"name"`
+		} `json:"projects"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil || len(result.Projects) != 1 || result.Projects[0].Name != "api" {
+		t.Errorf("expected the list document on stdout, got %q (%v)", stdout, err)
+	}
+	if stderr != "" {
+		t.Errorf("expected nothing on stderr, got %q", stderr)
+	}
+}
+
+// captureOutput devolve o que fn escreve no stdout e no stderr.
+func captureOutput(t *testing.T, fn func()) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	files := make([]*os.File, 2)
+	for i := range files {
+		f, err := os.Create(filepath.Join(dir, fmt.Sprint(i)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		files[i] = f
+	}
+
+	stdout, stderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = files[0], files[1]
+	fn()
+	os.Stdout, os.Stderr = stdout, stderr
+
+	out, _ := os.ReadFile(files[0].Name())
+	errOut, _ := os.ReadFile(files[1].Name())
+	return string(out), string(errOut)
+}
+
+// withStdout descarta o que fn escreve no stdout.
+func withStdout(t *testing.T, fn func()) {
+	t.Helper()
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer devNull.Close()
+
+	stdout := os.Stdout
+	os.Stdout = devNull
+	defer func() { os.Stdout = stdout }()
+	fn()
+}
@@ -8,8 +8,10 @@ import (
 	"runtime"
 
 	"github.com/Disneyjr/dcm/internal/commands"
+	"github.com/Disneyjr/dcm/internal/i18n"
 	"github.com/Disneyjr/dcm/utils"
 	"github.com/Disneyjr/dcm/utils/messages"
+	"github.com/Disneyjr/dcm/utils/terminal"
 )
 
 func findDCMBinary() (string, error) {
@@ -22,28 +24,30 @@ func findDCMBinary() (string, error) {
 		return abs, nil
 	}
 
-	return "", fmt.Errorf("binário '%s' não encontrado no diretório atual", baseName)
+	return "", i18n.Errorf("install.binary_not_found", baseName)
 }
 func main() {
-	fmt.Printf("\n%s DCM - Instalador Global\n\n", utils.Colorize("cyan", "📌"))
+	i18n.SetLocale(i18n.DetectLocale())
+	terminal.Setup(terminal.ColorAuto, false)
+	fmt.Printf("\n%s%s\n\n", utils.Icon("cyan", "📌"), i18n.T("install.title"))
 	defer messages.ExitMessage()
 	flag.Parse()
 	args := flag.Args()
 	if len(args) == 0 {
 		if !utils.IsAdmin() {
-			fmt.Println("📌 DCM - Instalador Global")
-			fmt.Println("❌ ERRO: Necessário privilégios de Administrador")
-			fmt.Println("💡 Clique direito no install.exe > 'Executar como administrador'")
+			fmt.Printf("%s%s\n", utils.Icon("", "📌"), i18n.T("install.title"))
+			fmt.Printf("%s%s\n", utils.Icon("", "❌"), i18n.T("install.admin_required"))
+			fmt.Printf("%s%s\n", utils.Icon("", "💡"), i18n.T("install.run_as_admin", "install.exe"))
 			return
 		}
 		sourcePath, err := findDCMBinary()
 		if err != nil {
-			fmt.Printf("%s %v\n", utils.Colorize("red", "❌"), err)
-			fmt.Printf("%s\nUso: Coloque dcm no diretório atual e execute este instalador.\n\n", utils.Colorize("yellow", "💡"))
+			fmt.Printf("%s%v\n", utils.Icon("red", "❌"), err)
+			fmt.Printf("%s%s\n\n", utils.Icon("yellow", "💡"), i18n.T("install.usage"))
 			return
 		}
 
-		fmt.Printf("%s Encontrado: %s\n", utils.Colorize("green", "✅"), sourcePath)
+		fmt.Printf("%s%s\n", utils.Icon("green", "✅"), i18n.T("install.source_found", sourcePath))
 
 		var installedPath string
 		var installErr error
@@ -53,19 +57,19 @@ func main() {
 		case "windows":
 			installedPath, installErr = commands.InstallWindows(sourcePath)
 		default:
-			installErr = fmt.Errorf("SO não suportado: %s", runtime.GOOS)
+			installErr = i18n.Errorf("install.unsupported_os", runtime.GOOS)
 		}
 
 		if installErr != nil {
-			fmt.Printf("%s %v\n", utils.Colorize("red", "❌"), installErr)
+			fmt.Printf("%s%v\n", utils.Icon("red", "❌"), installErr)
 			return
 		}
 
 		if err := commands.VerifyInstallation(installedPath); err != nil {
-			fmt.Printf("%s %v\n", utils.Colorize("red", "❌"), err)
-			fmt.Printf("\n%s Tente executar manualmente:\n", utils.Colorize("yellow", "💡"))
+			fmt.Printf("%s%v\n", utils.Icon("red", "❌"), err)
+			fmt.Printf("\n%s%s\n", utils.Icon("yellow", "💡"), i18n.T("install.try_manually"))
 			fmt.Printf("  Linux/macOS: sudo mv dcm /usr/local/bin/ && sudo chmod +x /usr/local/bin/dcm\n")
-			fmt.Printf("  Windows: Mova dcm.exe para C:\\Windows\\System32\\ (execute como Admin)\n\n")
+			fmt.Printf("  Windows: %s\n\n", i18n.T("install.manual_windows"))
 			return
 		}
 
@@ -6,18 +6,22 @@ import (
 	"path/filepath"
 	"runtime"
 
+	"github.com/Disneyjr/dcm/internal/i18n"
 	"github.com/Disneyjr/dcm/utils"
 	"github.com/Disneyjr/dcm/utils/messages"
+	"github.com/Disneyjr/dcm/utils/terminal"
 )
 
 func main() {
-	fmt.Printf("\n%s DCM - Desinstalador\n\n", utils.Colorize("cyan", "🗑️"))
+	i18n.SetLocale(i18n.DetectLocale())
+	terminal.Setup(terminal.ColorAuto, false)
+	fmt.Printf("\n%s%s\n\n", utils.Icon("cyan", "🗑️"), i18n.T("uninstall.title"))
 	defer messages.ExitMessage()
 
 	if !utils.IsAdmin() {
-		fmt.Println("🗑️  DCM - Desinstalador")
-		fmt.Println("❌ ERRO: Necessário privilégios de Administrador")
-		fmt.Println("💡 Clique direito no uninstall.exe > 'Executar como administrador'")
+		fmt.Printf("%s%s\n", utils.Icon("", "🗑️"), i18n.T("uninstall.title"))
+		fmt.Printf("%s%s\n", utils.Icon("", "❌"), i18n.T("install.admin_required"))
+		fmt.Printf("%s%s\n", utils.Icon("", "💡"), i18n.T("install.run_as_admin", "uninstall.exe"))
 		return
 	}
 
@@ -32,7 +36,7 @@ func main() {
 		targetPath = filepath.Join(os.Getenv("WINDIR"), "System32", "dcm.exe")
 		binaryName = "dcm.exe"
 	default:
-		fmt.Printf("%s SO não suportado: %s\n", utils.Colorize("red", "❌"), runtime.GOOS)
+		fmt.Printf("%s%s\n", utils.Icon("red", "❌"), i18n.T("install.unsupported_os", runtime.GOOS))
 		return
 	}
 
@@ -40,21 +44,21 @@ func main() {
 	_, err := os.Stat(targetPath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			fmt.Printf("%s DCM não está instalado\n", utils.Colorize("yellow", "⚠️"))
-			fmt.Printf("Caminho verificado: %s\n", targetPath)
+			fmt.Printf("%s%s\n", utils.Icon("yellow", "⚠️"), i18n.T("uninstall.not_installed"))
+			fmt.Println(i18n.T("uninstall.checked_path", targetPath))
 			return
 		}
-		fmt.Printf("%s Erro ao verificar instalação: %v\n", utils.Colorize("red", "❌"), err)
+		fmt.Printf("%s%s\n", utils.Icon("red", "❌"), i18n.T("uninstall.check_error", err))
 		return
 	}
 
-	fmt.Printf("%s DCM encontrado em: %s\n", utils.Colorize("green", "✅"), targetPath)
-	fmt.Printf("%s Removendo %s...\n", utils.Colorize("blue", "🔧"), binaryName)
+	fmt.Printf("%s%s\n", utils.Icon("green", "✅"), i18n.T("uninstall.found", targetPath))
+	fmt.Printf("%s%s\n", utils.Icon("blue", "🔧"), i18n.T("uninstall.removing_binary", binaryName))
 
 	// Remover o binário
 	if err := os.Remove(targetPath); err != nil {
-		fmt.Printf("%s Erro ao remover: %v\n", utils.Colorize("red", "❌"), err)
-		fmt.Printf("\n%s Tente executar manualmente:\n", utils.Colorize("yellow", "💡"))
+		fmt.Printf("%s%s\n", utils.Icon("red", "❌"), i18n.T("uninstall.remove_error", err))
+		fmt.Printf("\n%s%s\n", utils.Icon("yellow", "💡"), i18n.T("install.try_manually"))
 		if runtime.GOOS == "windows" {
 			fmt.Printf("  del \"%s\"\n\n", targetPath)
 		} else {
@@ -63,6 +67,6 @@ func main() {
 		return
 	}
 
-	fmt.Printf("\n%s DCM desinstalado com sucesso!\n", utils.Colorize("green", "✅"))
-	fmt.Printf("%s Obrigado por usar o DCM!\n\n", utils.Colorize("cyan", "👋"))
+	fmt.Printf("\n%s%s\n", utils.Icon("green", "✅"), i18n.T("uninstall.success"))
+	fmt.Printf("%s%s\n\n", utils.Icon("cyan", "👋"), i18n.T("uninstall.thanks"))
 }
@@ -1,3 +1,10 @@
 module github.com/Disneyjr/dcm
 
 go 1.25.3
+
+require (
+	github.com/BurntSushi/toml v1.6.0
+	golang.org/x/sys v0.37.0
+	golang.org/x/term v0.36.0
+	gopkg.in/yaml.v3 v3.0.1
+)
@@ -0,0 +1,167 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/Disneyjr/dcm/internal/i18n"
+	"github.com/Disneyjr/dcm/utils"
+)
+
+// ComposeError descreve um comando do compose que terminou com erro.
+type ComposeError struct {
+	Project  string
+	Service  string // Vazio quando o comando vale para o projeto inteiro
+	Command  string
+	Args     []string
+	ExitCode int    // -1 quando o processo nem chegou a terminar (ex: executável não encontrado)
+	Stderr   string // Últimas linhas do stderr
+	Output   string // Últimas linhas de stdout e stderr, na ordem em que foram escritas
+	LogFile  string // Arquivo com a saída completa, se o Runner tiver um LogDir
+	Err      error
+}
+
+func (e *ComposeError) Error() string {
+	commandLine := strings.TrimSpace(e.Command + " " + strings.Join(e.Args, " "))
+	switch {
+	case errors.Is(e.Err, context.DeadlineExceeded):
+		return i18n.T("compose.timed_out", commandLine)
+	case errors.Is(e.Err, context.Canceled):
+		return i18n.T("compose.interrupted", commandLine)
+	case e.ExitCode < 0:
+		return i18n.T("compose.error", e.Err)
+	}
+	return i18n.T("compose.exit_code", commandLine, e.ExitCode)
+}
+
+func (e *ComposeError) Unwrap() []error { return []error{ErrComposeFailed, e.Err} }
+
+// tailBuffer guarda apenas as últimas linhas escritas, para que a captura não cresça sem limite.
+type tailBuffer struct {
+	mu      sync.Mutex
+	max     int
+	lines   []string
+	partial []byte
+}
+
+func newTailBuffer(max int) *tailBuffer {
+	return &tailBuffer{max: max}
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	data := append(b.partial, p...)
+	for {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			break
+		}
+		b.push(strings.TrimRight(string(data[:i]), "\r"))
+		data = data[i+1:]
+	}
+	b.partial = append([]byte{}, data...)
+	return len(p), nil
+}
+
+func (b *tailBuffer) push(line string) {
+	if b.max <= 0 {
+		return
+	}
+	if len(b.lines) == b.max {
+		b.lines = b.lines[1:]
+	}
+	b.lines = append(b.lines, line)
+}
+
+// Lines devolve as linhas guardadas, incluindo a última mesmo sem quebra de linha no final.
+func (b *tailBuffer) Lines() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	lines := append([]string{}, b.lines...)
+	if len(b.partial) > 0 {
+		lines = append(lines, strings.TrimRight(string(b.partial), "\r"))
+		if b.max > 0 && len(lines) > b.max {
+			lines = lines[1:]
+		}
+	}
+	return lines
+}
+
+func (b *tailBuffer) String() string {
+	return strings.Join(b.Lines(), "\n")
+}
+
+// openLogFile cria (ou continua) o arquivo de log de um spec dentro de r.LogDir; todos os comandos
+// do spec na mesma execução vão para o mesmo arquivo. Falhas viram um aviso: o log é um
+// complemento e nunca deve impedir o comando de rodar.
+func (r *Runner) openLogFile(spec string) *os.File {
+	if r.LogDir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(r.LogDir, 0755); err != nil {
+		r.printf("%s%s\n", utils.Icon("yellow", "⚠️"), i18n.T("logs.dir_error", r.LogDir, err))
+		return nil
+	}
+
+	name := strings.ReplaceAll(spec, ":", "_") + ".log"
+	f, err := os.OpenFile(filepath.Join(r.LogDir, name), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		r.printf("%s%s\n", utils.Icon("yellow", "⚠️"), i18n.T("logs.file_error", spec, err))
+		return nil
+	}
+	return f
+}
+
+// newComposeError monta o erro de um comando que falhou a partir do que foi capturado.
+func newComposeError(spec, command string, args []string, err error, stderr, output *tailBuffer, logFile string) *ComposeError {
+	projectName, service := splitServiceSpec(spec)
+	composeErr := &ComposeError{
+		Project:  projectName,
+		Service:  service,
+		Command:  command,
+		Args:     args,
+		ExitCode: -1,
+		Stderr:   stderr.String(),
+		Output:   output.String(),
+		LogFile:  logFile,
+		Err:      err,
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		composeErr.ExitCode = exitErr.ExitCode()
+	}
+	return composeErr
+}
+
+// printFailureOutput mostra o final da saída de um comando que rodou sem exibir nada no terminal.
+func (r *Runner) printFailureOutput(spec string, e *ComposeError) {
+	if e.Output == "" && e.LogFile == "" {
+		return
+	}
+
+	var b strings.Builder
+	if e.Output != "" {
+		lines := strings.Split(e.Output, "\n")
+		fmt.Fprintf(&b, "%s%s\n", utils.Icon("yellow", "📄"), i18n.T("compose.output_tail", spec, len(lines)))
+		for _, line := range lines {
+			fmt.Fprintf(&b, "   │ %s\n", line)
+		}
+	}
+	if e.LogFile != "" {
+		fmt.Fprintf(&b, "   %s\n", i18n.T("compose.full_log", e.LogFile))
+	}
+	// Uma única escrita, para não intercalar com a saída de outros serviços em paralelo
+	io.WriteString(r.ErrOut, b.String())
+}
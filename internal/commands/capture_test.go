@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Disneyjr/dcm/internal/workspace"
+)
+
+func TestTailBuffer(t *testing.T) {
+	b := newTailBuffer(2)
+	b.Write([]byte("um\ndois\ntr"))
+	b.Write([]byte("ês\r\nquatro"))
+
+	if lines := b.Lines(); strings.Join(lines, ",") != "três,quatro" {
+		t.Errorf("unexpected lines: %q", lines)
+	}
+}
+
+func TestRunCommandFailureCapture(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("usa sh")
+	}
+
+	dir := t.TempDir()
+	r, buf := testRunner(&workspace.Workspace{})
+	r.LogDir = filepath.Join(dir, "logs")
+
+	err := r.runCommand(context.Background(), dir, "api:web", "sh", []string{"-c", "echo iniciando; echo porta em uso >&2; exit 3"}, true)
+	out := buf.String()
+
+	var composeErr *ComposeError
+	if !errors.As(err, &composeErr) {
+		t.Fatalf("expected ComposeError, got %v", err)
+	}
+	if !errors.Is(err, ErrComposeFailed) {
+		t.Error("expected error to be classified as compose failure")
+	}
+	if composeErr.Project != "api" || composeErr.Service != "web" || composeErr.ExitCode != 3 {
+		t.Errorf("unexpected error fields: %+v", composeErr)
+	}
+	if composeErr.Stderr != "porta em uso" {
+		t.Errorf("expected only stderr to be kept, got %q", composeErr.Stderr)
+	}
+	if !strings.Contains(out, "│ iniciando") || !strings.Contains(out, "│ porta em uso") {
+		t.Errorf("expected captured output to be shown on failure:\n%s", out)
+	}
+
+	log, readErr := os.ReadFile(filepath.Join(r.LogDir, "api_web.log"))
+	if readErr != nil {
+		t.Fatalf("expected log file: %v", readErr)
+	}
+	if !strings.Contains(string(log), "$ cd "+dir+" && sh -c") || !strings.Contains(string(log), "porta em uso") {
+		t.Errorf("unexpected log content:\n%s", log)
+	}
+}
+
+func TestRunCommandInterruption(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("usa sh")
+	}
+
+	r, _ := testRunner(&workspace.Workspace{})
+	r.GracePeriod = time.Second
+
+	// O processo ignora o SIGINT do timeout e precisa ser morto ao fim do GracePeriod
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := r.runCommand(ctx, t.TempDir(), "api", "sh", []string{"-c", "trap '' INT; sleep 10"}, true)
+
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "tempo limite") {
+		t.Errorf("expected timeout error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected process to be killed after the grace period, took %s", elapsed)
+	}
+}
@@ -1,6 +1,8 @@
 package commands
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -9,32 +11,21 @@ import (
 	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 
+	"github.com/Disneyjr/dcm/internal/i18n"
+	"github.com/Disneyjr/dcm/internal/state"
 	"github.com/Disneyjr/dcm/internal/workspace"
 	"github.com/Disneyjr/dcm/utils"
 )
 
-var DryRun = false
-
-func UpService(workspace *workspace.Workspace, serviceSpec string, verbose bool, extraArgs ...string) error {
-	parts := strings.Split(serviceSpec, ":")
-	projectName := parts[0]
-	targetService := ""
-	if len(parts) > 1 {
-		targetService = parts[1]
-	}
-
-	project, exists := workspace.Projects[projectName]
+// upService inicia um spec da operação run e espera a sua readiness. Com capture a saída do compose
+// só aparece se o comando falhar.
+func (r *Runner) upService(ctx context.Context, run *state.Run, serviceSpec string, capture bool, extraArgs ...string) error {
+	projectName, targetService := splitServiceSpec(serviceSpec)
+	project, exists := r.Workspace.Projects[projectName]
 	if !exists {
-		return fmt.Errorf("projeto '%s' não encontrado", projectName)
-	}
-
-	if verbose {
-		statusMsg := projectName
-		if targetService != "" {
-			statusMsg = fmt.Sprintf("%s:%s", projectName, targetService)
-		}
-		fmt.Printf("%s Iniciando %s\n", utils.Colorize("blue", "🚀"), statusMsg)
+		return i18n.Errorf("workspace.project_not_found", projectName)
 	}
 
 	args := []string{"up", "-d"}
@@ -43,25 +34,23 @@ func UpService(workspace *workspace.Workspace, serviceSpec string, verbose bool,
 		args = append(args, targetService)
 	}
 
-	if err := runCommand(project.Path, "docker-compose", args, !verbose); err != nil {
+	if err := r.runCompose(ctx, project, serviceSpec, args, capture); err != nil {
 		return err
 	}
 
-	if verbose {
-		fmt.Printf("%s ✅ %s pronto!\n", utils.Colorize("green", ""), projectName)
-	}
-	return nil
+	// O "up -d" retorna assim que os containers são criados; a readiness confirma que o serviço responde
+	return r.waitReady(ctx, run, project, serviceSpec)
 }
 
 func resolveGroupServices(ws *workspace.Workspace, groupName string, visited map[string]bool) ([]string, bool, error) {
 	if visited[groupName] {
-		return nil, true, fmt.Errorf("ciclo de herança detectado no grupo '%s'", groupName)
+		return nil, true, workspace.Invalid(i18n.Errorf("group.inheritance_cycle", groupName))
 	}
 	visited[groupName] = true
 
 	group, exists := ws.Groups[groupName]
 	if !exists {
-		return nil, true, fmt.Errorf("grupo '%s' não encontrado", groupName)
+		return nil, true, i18n.Errorf("workspace.group_not_found", groupName)
 	}
 
 	var allServices []string
@@ -86,202 +75,546 @@ func resolveGroupServices(ws *workspace.Workspace, groupName string, visited map
 	return allServices, parallel, nil
 }
 
-func UpGroup(workspace *workspace.Workspace, groupName string, extraArgs ...string) error {
-	services, parallel, err := resolveGroupServices(workspace, groupName, make(map[string]bool))
+func (r *Runner) UpGroup(ctx context.Context, groupName string, extraArgs ...string) (err error) {
+	ws := r.Workspace
+	services, parallel, err := resolveTargets(ws, groupName)
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("%s Iniciando grupo '%s' (parallel=%v)...\n\n", utils.Colorize("cyan", "🔄"), groupName, parallel)
+	plan, err := planStartup(ws, services)
+	if err != nil {
+		return err
+	}
 
-	if !parallel {
-		for _, serviceSpec := range services {
-			if err := UpService(workspace, serviceSpec, true, extraArgs...); err != nil {
-				fmt.Printf("%s %v\n", utils.Colorize("red", "❌"), err)
-			}
-		}
-	} else {
-		var wg sync.WaitGroup
-		errChan := make(chan error, len(services))
-
-		for _, s := range services {
-			wg.Add(1)
-			go func(spec string) {
-				defer wg.Done()
-				if err := UpService(workspace, spec, true, extraArgs...); err != nil {
-					errChan <- fmt.Errorf("%s %v", utils.Colorize("red", "❌"), err)
-				}
-			}(s)
-		}
+	policy := failurePolicy(ws, groupName, r.FailFast)
+	run := r.startRun("up", groupName, extraArgs, parallel)
+	defer func() { r.finishRun(run, err) }()
 
-		wg.Wait()
-		close(errChan)
+	// Para o rollback, guardamos apenas o que esta execução iniciou: specs que já estavam rodando ficam de fora
+	var mu sync.Mutex
+	started := make(map[string]bool)
 
-		hasError := false
-		for err := range errChan {
-			fmt.Println(err)
-			hasError = true
+	opts := planOptions{
+		Parallel:      parallel,
+		Limit:         concurrencyLimit(ws, groupName, r.Jobs),
+		StopOnFailure: policy != workspace.OnFailureContinue,
+	}
+	result := r.executePlan(ctx, run, plan, opts, func(spec string) error {
+		if policy == workspace.OnFailureRollback && !r.alreadyRunning(ctx, spec) {
+			mu.Lock()
+			started[spec] = true
+			mu.Unlock()
 		}
+		// Em paralelo a saída do compose é capturada, para não misturar as linhas de vários serviços
+		return r.upService(ctx, run, spec, parallel || r.Quiet, extraArgs...)
+	})
+	run.Specs = result.Succeeded
 
-		if hasError {
-			return fmt.Errorf("alguns serviços falharam ao iniciar")
-		}
+	// O rollback precisa terminar mesmo depois de um Ctrl+C, para não deixar a stack pela metade
+	if policy == workspace.OnFailureRollback && (result.failed() || ctx.Err() != nil) {
+		r.rollbackStarted(context.WithoutCancel(ctx), run, plan, started)
+		run.Specs = nil
+	}
+	if err := interruption(ctx); err != nil {
+		return err
 	}
 
-	fmt.Printf("\n%s ✨ Grupo pronto!\n", utils.Colorize("green", ""))
+	if result.failed() {
+		switch policy {
+		case workspace.OnFailureRollback:
+			// Depois do rollback nada desta execução continua rodando: não é uma falha parcial
+			return groupFailure(0, i18n.Errorf("up.rolled_back", groupName))
+		case workspace.OnFailureStop:
+			return result.failure(i18n.Errorf("up.stopped", groupName))
+		}
+		return result.failure(i18n.Errorf("up.some_failed"))
+	}
 	return nil
 }
 
-func DownAll(workspace *workspace.Workspace, removeVolumes bool) error {
-	volumeMsg := ""
-	if removeVolumes {
-		volumeMsg = " e removendo volumes"
+// alreadyRunning indica se o spec já tinha containers em execução antes do "up". Na dúvida
+// (engine indisponível) o spec é tratado como já em execução, para que o rollback não o pare.
+func (r *Runner) alreadyRunning(ctx context.Context, spec string) bool {
+	if r.DryRun {
+		return false
 	}
-	fmt.Printf("%s Parando todos os serviços%s...\n\n", utils.Colorize("cyan", "⏹️"), volumeMsg)
 
-	for projectName, project := range workspace.Projects {
-		fmt.Printf("%s Parando %s\n", utils.Colorize("blue", "🚀"), projectName)
+	projectName, targetService := splitServiceSpec(spec)
+	containers, err := r.composeContainers(ctx, r.Workspace.Projects[projectName], targetService)
+	if err != nil {
+		return true
+	}
+	for _, c := range containers {
+		if c.State == "running" {
+			return true
+		}
+	}
+	return false
+}
 
-		args := []string{"down"}
-		if removeVolumes {
-			args = append(args, "-v")
+// rollbackStarted para, na ordem inversa da inicialização, os specs iniciados pela execução atual.
+func (r *Runner) rollbackStarted(ctx context.Context, run *state.Run, plan *startupPlan, started map[string]bool) {
+	var specs []string
+	for i := len(plan.Order) - 1; i >= 0; i-- {
+		if started[plan.Order[i]] {
+			specs = append(specs, plan.Order[i])
 		}
+	}
+	if len(specs) == 0 {
+		return
+	}
 
-		if err := runCommand(project.Path, "docker-compose", args, true); err != nil {
-			fmt.Printf("%s Erro em %s: %v\n", utils.Colorize("red", "❌"), projectName, err)
+	e := r.event(run, RollbackStarted, "")
+	e.Specs = specs
+	r.emit(e)
+	for _, spec := range specs {
+		r.emit(r.event(run, RollbackStepStarted, spec))
+		if err := r.DownService(ctx, spec, false); err != nil {
+			failed := r.event(run, RollbackStepFailed, spec)
+			failed.Err = err
+			r.emit(failed)
 		}
 	}
+}
 
-	fmt.Printf("\n%s ✨ Todos parados!\n\n", utils.Colorize("green", ""))
-	return nil
+// planOptions controla como executePlan percorre o plano.
+type planOptions struct {
+	Parallel      bool
+	Limit         int  // Máximo de execuções simultâneas (0 = sem limite)
+	StopOnFailure bool // Depois da primeira falha, nenhum spec novo é iniciado
 }
 
-func DownGroup(workspace *workspace.Workspace, groupName string, removeVolumes bool) error {
-	services, _, err := resolveGroupServices(workspace, groupName, make(map[string]bool))
-	if err != nil {
-		return err
+// planResult resume uma execução de executePlan.
+type planResult struct {
+	Succeeded  []string
+	Failed     []string // Inclui os ignorados porque uma dependência falhou
+	NotStarted []string // Não executados porque a execução foi interrompida
+}
+
+func (p planResult) failed() bool {
+	return len(p.Failed) > 0
+}
+
+// failure classifica err como falha parcial ou total, conforme algum spec tenha dado certo.
+func (p planResult) failure(err error) error {
+	return groupFailure(len(p.Succeeded), err)
+}
+
+// errNotStarted marca os specs que não chegaram a ser executados porque a execução foi interrompida.
+var errNotStarted error = i18n.Message("plan.not_started")
+
+// executePlan executa fn para cada spec do plano: em sequência na ordem topológica ou em paralelo
+// por etapa, com no máximo opts.Limit execuções simultâneas. Serviços cuja dependência falhou não
+// são executados, e nada novo começa depois que ctx é cancelado. Cada spec vira um step de run.
+func (r *Runner) executePlan(ctx context.Context, run *state.Run, plan *startupPlan, opts planOptions, fn func(spec string) error) planResult {
+	failed := make(map[string]bool)
+	blockedBy := func(spec string) string {
+		for _, dep := range plan.Deps[spec] {
+			if failed[dep] {
+				return dep
+			}
+		}
+		return ""
 	}
 
-	volumeMsg := ""
-	if removeVolumes {
-		volumeMsg = " e removendo volumes"
+	waves := plan.Waves
+	if !opts.Parallel {
+		waves = make([][]string, len(plan.Order))
+		for i, spec := range plan.Order {
+			waves[i] = []string{spec}
+		}
 	}
-	fmt.Printf("%s Parando grupo '%s'%s...\n\n", utils.Colorize("cyan", "⏹️"), groupName, volumeMsg)
 
-	for _, serviceSpec := range services {
-		parts := strings.Split(serviceSpec, ":")
-		projectName := parts[0]
+	var stopped atomic.Bool
+	var result planResult
 
-		project, exists := workspace.Projects[projectName]
-		if !exists {
-			fmt.Printf("%s Projeto '%s' não encontrado\n", utils.Colorize("red", "❌"), projectName)
+	for i, wave := range waves {
+		if stopped.Load() || ctx.Err() != nil {
+			for _, spec := range wave {
+				r.skip(run, spec, errNotStarted)
+			}
+			result.NotStarted = append(result.NotStarted, wave...)
 			continue
 		}
+		if opts.Parallel {
+			e := r.event(run, WaveStarted, "")
+			e.Wave, e.Waves, e.Specs = i+1, len(waves), wave
+			r.emit(e)
+		}
 
-		fmt.Printf("%s Parando %s\n", utils.Colorize("blue", "🚀"), projectName)
-
-		args := []string{"down"}
-		if removeVolumes {
-			args = append(args, "-v")
+		var runnable []string
+		for _, spec := range wave {
+			if dep := blockedBy(spec); dep != "" {
+				r.skip(run, spec, i18n.Errorf("plan.skipped", spec, dep))
+				failed[spec] = true
+				result.Failed = append(result.Failed, spec)
+				continue
+			}
+			runnable = append(runnable, spec)
 		}
 
-		if err := runCommand(project.Path, "docker-compose", args, true); err != nil {
-			fmt.Printf("%s Erro em %s: %v\n", utils.Colorize("red", "❌"), projectName, err)
+		// Os workers pegam specs sob demanda, então os que ainda não começaram veem a interrupção
+		errs := runParallel(runnable, opts.Limit, func(spec string) error {
+			if stopped.Load() || ctx.Err() != nil {
+				r.skip(run, spec, errNotStarted)
+				return errNotStarted
+			}
+			err := r.step(run, spec, func() error { return fn(spec) })
+			if err != nil && opts.StopOnFailure {
+				stopped.Store(true)
+			}
+			return err
+		})
+		for j, err := range errs {
+			switch {
+			case errors.Is(err, errNotStarted):
+				result.NotStarted = append(result.NotStarted, runnable[j])
+			case err != nil:
+				failed[runnable[j]] = true
+				result.Failed = append(result.Failed, runnable[j])
+			default:
+				result.Succeeded = append(result.Succeeded, runnable[j])
+			}
 		}
 	}
+	return result
+}
 
-	fmt.Printf("\n%s ✨ Grupo '%s' parado!\n\n", utils.Colorize("green", ""), groupName)
-	return nil
+// runParallel executa fn para cada spec com um pool de no máximo limit workers (0 = um por spec)
+// e devolve todos os erros na mesma ordem dos specs, independentemente da ordem de término.
+func runParallel(specs []string, limit int, fn func(spec string) error) []error {
+	errs := make([]error, len(specs))
+	workers := len(specs)
+	if limit > 0 && limit < workers {
+		workers = limit
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				errs[i] = fn(specs[i])
+			}
+		}()
+	}
+
+	for i := range specs {
+		jobs <- i
+	}
+	close(jobs)
+
+	wg.Wait()
+	return errs
 }
 
-func RestartAll(workspace *workspace.Workspace) error {
-	fmt.Printf("%s Reiniciando todos os serviços...\n\n", utils.Colorize("cyan", "🔄"))
+// groupChain retorna o grupo e os grupos que ele estende, do mais específico ao mais genérico.
+// Alvos que não são grupos resultam em uma cadeia vazia.
+func groupChain(ws *workspace.Workspace, target string) []workspace.Group {
+	var chain []workspace.Group
+	visited := make(map[string]bool)
+	for name := target; name != "" && !visited[name]; {
+		visited[name] = true
+		group, exists := ws.Groups[name]
+		if !exists {
+			break
+		}
+		chain = append(chain, group)
+		name = group.Extends
+	}
+	return chain
+}
 
-	for projectName, project := range workspace.Projects {
-		fmt.Printf("%s Reiniciando %s\n", utils.Colorize("blue", "🚀"), projectName)
-		if err := runCommand(project.Path, "docker-compose", []string{"restart"}, true); err != nil {
-			fmt.Printf("%s Erro em %s: %v\n", utils.Colorize("red", "❌"), projectName, err)
+// concurrencyLimit retorna o limite de execuções simultâneas para o alvo: jobs (flag --jobs) ou o
+// maxParallel do grupo (herdado via extends quando o grupo não define o seu).
+func concurrencyLimit(ws *workspace.Workspace, target string, jobs int) int {
+	if jobs > 0 {
+		return jobs
+	}
+	for _, group := range groupChain(ws, target) {
+		if group.MaxParallel > 0 {
+			return group.MaxParallel
 		}
 	}
+	return 0
+}
 
-	fmt.Printf("\n%s ✨ Todos reiniciados!\n\n", utils.Colorize("green", ""))
-	return nil
+// failurePolicy retorna o onFailure do alvo (herdado via extends; padrão "continue").
+// Com failFast (--fail-fast), "continue" vira "stop"; "rollback" é mantido.
+func failurePolicy(ws *workspace.Workspace, target string, failFast bool) string {
+	policy := workspace.OnFailureContinue
+	for _, group := range groupChain(ws, target) {
+		if group.OnFailure != "" {
+			policy = group.OnFailure
+			break
+		}
+	}
+	if failFast && policy == workspace.OnFailureContinue {
+		return workspace.OnFailureStop
+	}
+	return policy
 }
 
-func StatusAll(workspace *workspace.Workspace) error {
-	fmt.Printf("%s Status de todos os serviços:\n\n", utils.Colorize("cyan", "📊"))
+func (r *Runner) DownAll(ctx context.Context, removeVolumes bool) (err error) {
+	run := r.startRun("down", "", downArgs(removeVolumes), false)
+	defer func() { r.finishRun(run, err) }()
+
+	projectNames := sortedProjectNames(r.Workspace)
 
-	for projectName, project := range workspace.Projects {
-		fmt.Printf("%s %s:\n", utils.Colorize("blue", "📌"), projectName)
-		if err := runCommand(project.Path, "docker-compose", []string{"ps"}, false); err != nil {
-			fmt.Printf("%s Erro: %v\n", utils.Colorize("red", "❌"), err)
+	// Para na ordem inversa da inicialização: quem depende para antes das suas dependências
+	order := projectNames
+	if plan, err := planStartup(r.Workspace, projectNames); err == nil {
+		order = plan.Order
+	} else {
+		r.printf("%s%s\n", utils.Icon("yellow", "⚠️"), i18n.T("down.alphabetical_order", err))
+	}
+
+	failed := 0
+	for i := len(order) - 1; i >= 0; i-- {
+		if ctx.Err() != nil {
+			break
 		}
-		fmt.Println()
+		if err := r.downStep(ctx, run, order[i], removeVolumes); err != nil {
+			failed++
+			continue
+		}
+		run.Specs = append(run.Specs, order[i])
+	}
+	if err := interruption(ctx); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return groupFailure(len(order)-failed, i18n.Errorf("down.projects_failed", failed))
+	}
+	return nil
+}
+
+func (r *Runner) DownGroup(ctx context.Context, groupName string, removeVolumes bool) (err error) {
+	services, parallel, err := resolveTargets(r.Workspace, groupName)
+	if err != nil {
+		return err
+	}
+
+	plan, err := planStartup(r.Workspace, services)
+	if err != nil {
+		return err
 	}
+	// Dependências de fora do alvo, como um banco compartilhado com outros grupos, continuam rodando
+	plan = plan.targetsOnly()
 
+	run := r.startRun("down", groupName, downArgs(removeVolumes), parallel)
+	defer func() { r.finishRun(run, err) }()
+
+	// O desligamento é o inverso exato da inicialização
+	failed := 0
+	if !parallel {
+		for i := len(plan.Order) - 1; i >= 0 && ctx.Err() == nil; i-- {
+			if err := r.downStep(ctx, run, plan.Order[i], removeVolumes); err != nil {
+				failed++
+				continue
+			}
+			run.Specs = append(run.Specs, plan.Order[i])
+		}
+	} else {
+		for i := len(plan.Waves) - 1; i >= 0 && ctx.Err() == nil; i-- {
+			wave := plan.Waves[i]
+			errs := runParallel(wave, concurrencyLimit(r.Workspace, groupName, r.Jobs), func(spec string) error {
+				return r.downStep(ctx, run, spec, removeVolumes)
+			})
+			for j, err := range errs {
+				if err != nil {
+					failed++
+					continue
+				}
+				run.Specs = append(run.Specs, wave[j])
+			}
+		}
+	}
+	if err := interruption(ctx); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return groupFailure(len(plan.Order)-failed, i18n.Errorf("down.group_services_failed", failed, groupName))
+	}
 	return nil
 }
 
-func LogsAll(workspace *workspace.Workspace) error {
-	fmt.Printf("%s Logs de todos os serviços:\n\n", utils.Colorize("cyan", "📋"))
+// downStep para um spec como step de run.
+func (r *Runner) downStep(ctx context.Context, run *state.Run, spec string, removeVolumes bool) error {
+	return r.step(run, spec, func() error { return r.DownService(ctx, spec, removeVolumes) })
+}
+
+// DownService para um projeto inteiro com "down" ou, para um spec "projeto:serviço",
+// apenas o serviço indicado com "rm --stop", sem derrubar o resto do projeto.
+func (r *Runner) DownService(ctx context.Context, serviceSpec string, removeVolumes bool) error {
+	projectName, targetService := splitServiceSpec(serviceSpec)
+
+	project, exists := r.Workspace.Projects[projectName]
+	if !exists {
+		return i18n.Errorf("workspace.project_not_found", projectName)
+	}
+
+	args := []string{"down"}
+	if targetService != "" {
+		args = []string{"rm", "--stop", "--force"}
+	}
+	if removeVolumes {
+		args = append(args, "-v")
+	}
+	if targetService != "" {
+		args = append(args, targetService)
+	}
+
+	return r.runCompose(ctx, project, serviceSpec, args, true)
+}
+
+func (r *Runner) StatusGroup(ctx context.Context, groupName string) error {
+	services, _, err := resolveTargets(r.Workspace, groupName)
+	if err != nil {
+		return err
+	}
 
-	for projectName, project := range workspace.Projects {
-		fmt.Printf("%s %s:\n", utils.Colorize("blue", "📌"), projectName)
-		if err := runCommand(project.Path, "docker-compose", []string{"logs"}, false); err != nil {
-			fmt.Printf("%s Erro: %v\n", utils.Colorize("red", "❌"), err)
+	if r.structuredOutput() {
+		return r.printStructured(r.buildStatusResult(ctx, services))
+	}
+
+	if groupName == "" {
+		r.printf("%s%s\n\n", utils.Icon("cyan", "📊"), i18n.T("status.all"))
+	} else {
+		r.printf("%s%s\n\n", utils.Icon("cyan", "📊"), i18n.T("status.group", groupName))
+	}
+
+	for _, spec := range services {
+		projectName, targetService := splitServiceSpec(spec)
+		project, exists := r.Workspace.Projects[projectName]
+		if !exists {
+			r.printf("%s%s\n", utils.Icon("red", "❌"), i18n.T("status.project_not_found", projectName))
+			continue
+		}
+
+		args := []string{"ps"}
+		if targetService != "" {
+			args = append(args, targetService)
+		}
+
+		r.printf("%s%s:\n", utils.Icon("blue", "📌"), spec)
+		if err := r.runCompose(ctx, project, spec, args, false); err != nil {
+			r.printf("%s%s\n", utils.Icon("red", "❌"), i18n.T("common.error", err))
+		}
+		r.printf("\n")
+
+		if err := interruption(ctx); err != nil {
+			return err
 		}
-		fmt.Println()
 	}
 
 	return nil
 }
 
-func ListAll(workspace *workspace.Workspace) {
-	fmt.Printf("%s Projetos:\n", utils.Colorize("cyan", "📌"))
-	for name, proj := range workspace.Projects {
-		fmt.Printf("  - %s: %s\n", name, proj.Description)
+func (r *Runner) ListAll() error {
+	ws := r.Workspace
+	if r.structuredOutput() {
+		return r.printStructured(buildListResult(ws))
+	}
+
+	r.printf("%s%s\n", utils.Icon("cyan", "📌"), i18n.T("list.projects"))
+	for _, name := range sortedProjectNames(ws) {
+		r.printf("  - %s: %s\n", name, ws.Projects[name].Description)
 	}
-	fmt.Printf("\n%s Grupos:\n", utils.Colorize("cyan", "📌"))
-	for name := range workspace.Groups {
-		fmt.Printf("  - %s\n", name)
+	r.printf("\n%s%s\n", utils.Icon("cyan", "📌"), i18n.T("list.groups"))
+	for _, name := range sortedGroupNames(ws) {
+		r.printf("  - %s\n", name)
 	}
-	fmt.Println()
+	r.printf("\n")
+	return nil
 }
 
-func InspectGroup(ws *workspace.Workspace, groupName string) {
-	services, parallel, err := resolveGroupServices(ws, groupName, make(map[string]bool))
+func (r *Runner) InspectGroup(groupName string) error {
+	result, err := r.buildInspectResult(groupName)
 	if err != nil {
-		fmt.Printf("%s %v\n", utils.Colorize("red", "❌"), err)
-		return
+		return err
+	}
+
+	if r.structuredOutput() {
+		return r.printStructured(result)
 	}
 
-	fmt.Printf("%s Inspeção do grupo: %s\n", utils.Colorize("cyan", "🔍"), groupName)
-	fmt.Printf("Configuração: parallel=%v\n\n", parallel)
-	fmt.Printf("Serviços na ordem de execução:\n")
-	for i, spec := range services {
-		parts := strings.Split(spec, ":")
-		projectName := parts[0]
-		targetService := "todos"
-		if len(parts) > 1 {
-			targetService = parts[1]
+	r.printf("%s%s\n", utils.Icon("cyan", "🔍"), i18n.T("inspect.title", groupName))
+	config := fmt.Sprintf("parallel=%v", result.Parallel)
+	if result.MaxParallel > 0 {
+		config += fmt.Sprintf(", maxParallel=%d", result.MaxParallel)
+	}
+	r.printf("%s\n\n", i18n.T("inspect.config", config, result.OnFailure))
+	r.printf("%s\n", i18n.T("inspect.services"))
+	for i, svc := range result.Services {
+		targetService := svc.Service
+		if targetService == "" {
+			targetService = i18n.T("inspect.all_services")
 		}
 
-		project := ws.Projects[projectName]
-		fmt.Printf("%d. %s\n", i+1, utils.Colorize("blue", spec))
-		fmt.Printf("   Caminho: %s\n", project.Path)
-		fmt.Printf("   Serviço: %s\n", targetService)
+		r.printf("%d. %s\n", i+1, utils.Colorize("blue", svc.Spec))
+		r.printf("   %s\n", i18n.T("inspect.path", svc.Path))
+		r.printf("   %s\n", i18n.T("inspect.service", targetService))
+		if len(svc.DependsOn) > 0 {
+			r.printf("   %s\n", i18n.T("inspect.depends_on", strings.Join(svc.DependsOn, ", ")))
+		}
 	}
-	fmt.Println()
+
+	if result.Parallel && len(result.Waves) > 1 {
+		r.printf("\n%s\n", i18n.T("inspect.waves"))
+		for i, wave := range result.Waves {
+			r.printf("%d. %s\n", i+1, strings.Join(wave, ", "))
+		}
+	}
+	r.printf("\n")
+	return nil
 }
 
-func ValidateWorkspace(ws *workspace.Workspace) {
-	fmt.Printf("%s Validando workspace.json...\n", utils.Colorize("cyan", "🔍"))
-	hasError := false
+// ValidateWorkspace imprime cada problema encontrado e retorna um erro de configuração inválida se
+// houver algum.
+func (r *Runner) ValidateWorkspace() error {
+	ws := r.Workspace
+	fileName := "workspace.json"
+	if ws.File != "" {
+		fileName = filepath.Base(ws.File)
+	}
+	r.printf("%s%s\n", utils.Icon("cyan", "🔍"), i18n.T("validate.validating", fileName))
+	problems := 0
+
+	if ws.Engine != "" {
+		if _, err := LookupEngine(ws.Engine); err != nil {
+			r.printf("%s%v\n", utils.Icon("red", "❌"), err)
+			problems++
+		}
+	}
 
 	for name, proj := range ws.Projects {
 		if _, err := os.Stat(proj.Path); os.IsNotExist(err) {
-			fmt.Printf("%s Projeto '%s': caminho não encontrado: %s\n", utils.Colorize("red", "❌"), name, proj.Path)
-			hasError = true
+			r.printf("%s%s\n", utils.Icon("red", "❌"), i18n.T("validate.path_not_found", name, proj.Path))
+			problems++
+		}
+		if proj.Readiness != nil {
+			if err := proj.Readiness.Validate(); err != nil {
+				r.printf("%s%s\n", utils.Icon("red", "❌"), i18n.T("validate.project_error", name, err))
+				problems++
+			}
+		}
+		for _, err := range validateComposeOptions(proj) {
+			r.printf("%s%s\n", utils.Icon("red", "❌"), i18n.T("validate.project_error", name, err))
+			problems++
+		}
+		if err := proj.ValidateTimeouts(); err != nil {
+			r.printf("%s%s\n", utils.Icon("red", "❌"), i18n.T("validate.project_error", name, err))
+			problems++
+		}
+		for _, taskName := range sortedTaskNames(proj) {
+			if err := proj.Tasks[taskName].Validate(); err != nil {
+				r.printf("%s%s\n", utils.Icon("red", "❌"), i18n.T("validate.task_error", name, taskName, err))
+				problems++
+			}
 		}
 	}
 
@@ -289,47 +622,73 @@ func ValidateWorkspace(ws *workspace.Workspace) {
 		for _, spec := range group.Services {
 			parts := strings.Split(spec, ":")
 			if _, exists := ws.Projects[parts[0]]; !exists {
-				fmt.Printf("%s Grupo '%s': projeto '%s' não definido\n", utils.Colorize("red", "❌"), name, parts[0])
-				hasError = true
+				r.printf("%s%s\n", utils.Icon("red", "❌"), i18n.T("validate.undefined_project", name, parts[0]))
+				problems++
 			}
 		}
+		if err := workspace.ValidateOnFailure(group.OnFailure); err != nil {
+			r.printf("%s%s\n", utils.Icon("red", "❌"), i18n.T("validate.group_error", name, err))
+			problems++
+		}
+		if group.MaxParallel < 0 {
+			r.printf("%s%s\n", utils.Icon("red", "❌"), i18n.T("validate.negative_max_parallel", name))
+			problems++
+		}
 		if group.Extends != "" {
 			if _, exists := ws.Groups[group.Extends]; !exists {
-				fmt.Printf("%s Grupo '%s': estende grupo inexistente '%s'\n", utils.Colorize("red", "❌"), name, group.Extends)
-				hasError = true
+				r.printf("%s%s\n", utils.Icon("red", "❌"), i18n.T("validate.unknown_extends", name, group.Extends))
+				problems++
+			}
+		}
+	}
+
+	for name, proj := range ws.Projects {
+		deps := append([]string{}, proj.DependsOn...)
+		for _, svc := range proj.Services {
+			deps = append(deps, svc.DependsOn...)
+		}
+		for _, dep := range deps {
+			depProject, _ := splitServiceSpec(dep)
+			if _, exists := ws.Projects[depProject]; !exists {
+				r.printf("%s%s\n", utils.Icon("red", "❌"), i18n.T("validate.undefined_dependency", name, dep))
+				problems++
 			}
 		}
 	}
 
-	if !hasError {
-		fmt.Printf("%s Workspace válido!\n", utils.Colorize("green", "✅"))
+	if problems == 0 {
+		if _, err := planStartup(ws, sortedProjectNames(ws)); err != nil {
+			r.printf("%s%v\n", utils.Icon("red", "❌"), err)
+			problems++
+		}
+	}
+
+	if problems > 0 {
+		return workspace.Invalid(i18n.Errorf("validate.invalid", fileName, problems))
 	}
+	r.printf("%s%s\n", utils.Icon("green", "✅"), i18n.T("validate.ok"))
+	return nil
 }
 
 func Uninstall() {
 	targetPath := filepath.Join(os.Getenv("WINDIR"), "System32", "dcm.exe")
 	_, err := os.Stat(targetPath)
 	if err != nil {
-		fmt.Println("dcm não encontrado!")
+		fmt.Println(i18n.T("uninstall.not_found"))
 		fmt.Println(err.Error())
-		fmt.Println("Uma pena que o dcm não atendeu o seu projeto!")
+		fmt.Println(i18n.T("uninstall.goodbye"))
 		return
 	}
 
-	fmt.Printf("🗑️  Removendo %s\n", targetPath)
+	fmt.Printf("%s%s\n", utils.Icon("", "🗑️"), i18n.T("uninstall.removing", targetPath))
 	os.Remove(targetPath)
 
-	fmt.Println("✅ dcm desinstalado!")
-	fmt.Println("Uma pena que o dcm não atendeu o seu projeto!")
+	fmt.Printf("%s%s\n", utils.Icon("", "✅"), i18n.T("uninstall.done"))
+	fmt.Println(i18n.T("uninstall.goodbye"))
 }
 
-func InitWorkspace() error {
-	filePath := "workspace.json"
-	if _, err := os.Stat(filePath); err == nil {
-		return fmt.Errorf("workspace.json já existe")
-	}
-
-	content := `{
+var initTemplates = map[string]string{
+	workspace.FormatJSON: `{
   "version": "1.0",
   "projects": {
     "exemplo": { "path": "./services/exemplo", "description": "Projeto de exemplo" }
@@ -338,57 +697,150 @@ func InitWorkspace() error {
     "dev": { "services": ["exemplo"] }
   }
 }
-`
+`,
+	workspace.FormatYAML: `version: "1.0"
+
+# Cada projeto aponta para uma pasta com docker-compose.yml
+projects:
+  exemplo:
+    path: ./services/exemplo
+    description: Projeto de exemplo
+
+# Grupos combinam projetos ou specs "projeto:serviço"
+groups:
+  dev:
+    services:
+      - exemplo
+`,
+	workspace.FormatTOML: `version = "1.0"
+
+# Cada projeto aponta para uma pasta com docker-compose.yml
+[projects.exemplo]
+path = "./services/exemplo"
+description = "Projeto de exemplo"
+
+# Grupos combinam projetos ou specs "projeto:serviço"
+[groups.dev]
+services = ["exemplo"]
+`,
+}
+
+func InitWorkspace(format string) error {
+	if format == "yml" {
+		format = workspace.FormatYAML
+	}
+	content, ok := initTemplates[format]
+	if !ok {
+		return i18n.Errorf("init.invalid_format", format)
+	}
+
+	for _, name := range workspace.FileNames {
+		if _, err := os.Stat(name); err == nil {
+			return i18n.Errorf("init.exists", name)
+		}
+	}
+
+	filePath := "workspace." + format
 	err := os.WriteFile(filePath, []byte(content), 0644)
 	if err != nil {
-		return fmt.Errorf("erro ao criar workspace.json: %w", err)
+		return i18n.Errorf("init.create_error", filePath, err)
 	}
 
-	fmt.Printf("%s workspace.json criado com sucesso!\n", utils.Colorize("green", "✅"))
+	fmt.Printf("%s%s\n", utils.Icon("green", "✅"), i18n.T("init.created", filePath))
 	return nil
 }
 
-func runCommand(projectPath string, command string, args []string, parallel bool) error {
-	if DryRun {
-		fmt.Printf("%s [DRY-RUN] cd %s && %s %s\n", utils.Colorize("yellow", "🛠️"), projectPath, command, strings.Join(args, " "))
+// composeOperations relaciona os subcomandos do compose com as chaves de "timeouts" do projeto.
+var composeOperations = map[string]string{
+	"up":      "up",
+	"down":    "down",
+	"rm":      "down", // "down" de um serviço específico
+	"restart": "restart",
+	"pull":    "pull",
+	"build":   "build",
+}
+
+func (r *Runner) runCompose(ctx context.Context, project workspace.Project, spec string, args []string, parallel bool) error {
+	if len(args) > 0 {
+		// Um timeout inválido já é apontado pelo "dcm validate"; aqui ele apenas não é aplicado
+		if timeout, _ := project.Timeout(composeOperations[args[0]]); timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+	}
+	return r.runCommand(ctx, project.Path, spec, r.Engine.Command, r.Engine.composeArgs(project, args), parallel)
+}
+
+// runCommand executa um comando no diretório do projeto. Com parallel (e sem Verbose) a saída não
+// vai para r.Out, mas as últimas linhas ficam guardadas e são exibidas se o comando falhar.
+func (r *Runner) runCommand(ctx context.Context, projectPath string, spec string, command string, args []string, parallel bool) error {
+	if r.DryRun {
+		r.printf("%s[DRY-RUN] cd %s && %s %s\n", utils.Icon("yellow", "🛠️"), projectPath, command, strings.Join(args, " "))
 		return nil
 	}
 
-	c := exec.Command(command, args...)
+	if r.Verbose {
+		r.printf("%s cd %s && %s %s\n", utils.Colorize("magenta", "$"), projectPath, command, strings.Join(args, " "))
+	}
+
+	c := r.newCommand(ctx, command, args...)
 	c.Dir = projectPath
 
-	if !parallel {
-		c.Stdout = os.Stdout
-		c.Stderr = os.Stderr
-	} else {
-		c.Stdout = io.Discard
-		c.Stderr = io.Discard
+	captured := parallel && !r.Verbose
+	output := newTailBuffer(r.TailLines)
+	stderr := newTailBuffer(r.TailLines)
+	stdoutWriters := []io.Writer{output}
+	stderrWriters := []io.Writer{output, stderr}
+	if !captured {
+		stdoutWriters = []io.Writer{r.Out}
+		stderrWriters = []io.Writer{r.ErrOut, stderr}
+	}
+
+	logPath := ""
+	if logFile := r.openLogFile(spec); logFile != nil {
+		defer logFile.Close()
+		logPath = logFile.Name()
+		fmt.Fprintf(logFile, "$ cd %s && %s %s\n", projectPath, command, strings.Join(args, " "))
+		stdoutWriters = append(stdoutWriters, logFile)
+		stderrWriters = append(stderrWriters, logFile)
 	}
 
+	c.Stdout = io.MultiWriter(stdoutWriters...)
+	c.Stderr = io.MultiWriter(stderrWriters...)
+
 	if err := c.Run(); err != nil {
-		return fmt.Errorf("erro: %w", err)
+		// Interrompido por Ctrl+C ou timeout: a causa importa mais que o código de saída
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			err = ctxErr
+		}
+		composeErr := newComposeError(spec, command, args, err, stderr, output, logPath)
+		if captured {
+			r.printFailureOutput(spec, composeErr)
+		}
+		return composeErr
 	}
 
 	return nil
 }
 
 func InstallLinuxMacOS(sourcePath string) (string, error) {
-	fmt.Printf("%s Detectado: %s\n", utils.Colorize("cyan", "🔍"), utils.GetSystemInfo())
-	fmt.Printf("%s Instalando DCM globalmente...\n\n", utils.Colorize("blue", "🚀"))
+	fmt.Printf("%s%s\n", utils.Icon("cyan", "🔍"), i18n.T("install.detected", utils.GetSystemInfo()))
+	fmt.Printf("%s%s\n\n", utils.Icon("blue", "🚀"), i18n.T("install.installing"))
 
 	targetPath := "/usr/local/bin/dcm"
 
-	fmt.Printf("%s Copiando binário para %s\n", utils.Colorize("cyan", "📁"), targetPath)
+	fmt.Printf("%s%s\n", utils.Icon("cyan", "📁"), i18n.T("install.copying", targetPath))
 
 	srcFile, err := os.Open(sourcePath)
 	if err != nil {
-		return "", fmt.Errorf("erro ao abrir arquivo: %w", err)
+		return "", i18n.Errorf("install.open_error", err)
 	}
 	defer srcFile.Close()
 
 	dstFile, err := os.Create(targetPath)
 	if err != nil {
-		fmt.Printf("%s Permissão negada, tentando com sudo...\n", utils.Colorize("yellow", "⚠️"))
+		fmt.Printf("%s%s\n", utils.Icon("yellow", "⚠️"), i18n.T("install.trying_sudo"))
 
 		cmd := exec.Command("sudo", "tee", targetPath)
 		cmd.Stdout = os.Stdout
@@ -396,38 +848,38 @@ func InstallLinuxMacOS(sourcePath string) (string, error) {
 
 		stdinPipe, err := cmd.StdinPipe()
 		if err != nil {
-			return "", fmt.Errorf("erro ao criar pipe: %w", err)
+			return "", i18n.Errorf("install.pipe_error", err)
 		}
 
 		if err := cmd.Start(); err != nil {
-			return "", fmt.Errorf("erro ao executar sudo: %w", err)
+			return "", i18n.Errorf("install.sudo_error", err)
 		}
 
 		if _, err := io.Copy(stdinPipe, srcFile); err != nil {
-			return "", fmt.Errorf("erro ao copiar arquivo: %w", err)
+			return "", i18n.Errorf("install.copy_error", err)
 		}
 
 		stdinPipe.Close()
 
 		if err := cmd.Wait(); err != nil {
-			return "", fmt.Errorf("erro ao finalizar cópia: %w", err)
+			return "", i18n.Errorf("install.finish_error", err)
 		}
 
-		fmt.Printf("%s Ajustando permissões...\n", utils.Colorize("cyan", "🔒"))
+		fmt.Printf("%s%s\n", utils.Icon("cyan", "🔒"), i18n.T("install.chmod"))
 		chmodCmd := exec.Command("sudo", "chmod", "+x", targetPath)
 		if err := chmodCmd.Run(); err != nil {
-			return "", fmt.Errorf("erro ao ajustar permissões: %w", err)
+			return "", i18n.Errorf("install.chmod_error", err)
 		}
 	} else {
 		defer dstFile.Close()
 
 		if _, err := io.Copy(dstFile, srcFile); err != nil {
-			return "", fmt.Errorf("erro ao copiar conteúdo: %w", err)
+			return "", i18n.Errorf("install.copy_error", err)
 		}
 
-		fmt.Printf("%s Ajustando permissões...\n", utils.Colorize("cyan", "🔒"))
+		fmt.Printf("%s%s\n", utils.Icon("cyan", "🔒"), i18n.T("install.chmod"))
 		if err := os.Chmod(targetPath, 0755); err != nil {
-			return "", fmt.Errorf("erro ao ajustar permissões: %w", err)
+			return "", i18n.Errorf("install.chmod_error", err)
 		}
 	}
 
@@ -435,34 +887,34 @@ func InstallLinuxMacOS(sourcePath string) (string, error) {
 }
 
 func InstallWindows(sourcePath string) (string, error) {
-	fmt.Printf("%s Detectado: %s\n", utils.Colorize("cyan", "🔍"), utils.GetSystemInfo())
-	fmt.Printf("%s Instalando DCM globalmente...\n\n", utils.Colorize("blue", "🚀"))
+	fmt.Printf("%s%s\n", utils.Icon("cyan", "🔍"), i18n.T("install.detected", utils.GetSystemInfo()))
+	fmt.Printf("%s%s\n\n", utils.Icon("blue", "🚀"), i18n.T("install.installing"))
 
 	targetPath := filepath.Join(os.Getenv("WINDIR"), "System32", "dcm.exe")
 
-	fmt.Printf("%s Copiando para: %s\n", utils.Colorize("cyan", "📁"), targetPath)
+	fmt.Printf("%s%s\n", utils.Icon("cyan", "📁"), i18n.T("install.copying", targetPath))
 
 	srcFile, err := os.Open(sourcePath)
 	if err != nil {
-		return "", fmt.Errorf("erro ao abrir arquivo: %w", err)
+		return "", i18n.Errorf("install.open_error", err)
 	}
 	defer srcFile.Close()
 
 	dstFile, err := os.Create(targetPath)
 	if err != nil {
-		return "", fmt.Errorf("erro ao criar destino (pode precisar executar como Admin): %w", err)
+		return "", i18n.Errorf("install.create_error", err)
 	}
 	defer dstFile.Close()
 
 	if _, err := io.Copy(dstFile, srcFile); err != nil {
-		return "", fmt.Errorf("erro ao copiar: %w", err)
+		return "", i18n.Errorf("install.copy_error", err)
 	}
 
 	return targetPath, nil
 }
 
 func VerifyInstallation(installedPath string) error {
-	fmt.Printf("\n%s Validando instalação...\n", utils.Colorize("cyan", "✓"))
+	fmt.Printf("\n%s%s\n", utils.Icon("cyan", "✓"), i18n.T("install.validating"))
 
 	if installedPath == "" {
 		cmdSearch := exec.Command("which", "dcm")
@@ -472,7 +924,7 @@ func VerifyInstallation(installedPath string) error {
 
 		output, err := cmdSearch.Output()
 		if err != nil {
-			return fmt.Errorf("DCM não encontrado no PATH")
+			return i18n.Errorf("install.not_in_path")
 		}
 		installedPath = strings.TrimSpace(string(output))
 		// 'where' can return multiple paths on Windows, use the first one
@@ -484,13 +936,13 @@ func VerifyInstallation(installedPath string) error {
 		}
 	}
 
-	fmt.Printf("%s Encontrado em: %s\n", utils.Colorize("green", "✅"), installedPath)
+	fmt.Printf("%s%s\n", utils.Icon("green", "✅"), i18n.T("install.found", installedPath))
 
 	// Use absolute path to run the version command to avoid Go's relative path execution security check
 	testCmd := exec.Command(installedPath, "version")
 	output, err := testCmd.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("erro ao executar '%s version': %w\nSaída: %s", installedPath, err, string(output))
+		return i18n.Errorf("install.version_error", installedPath, err, string(output))
 	}
 
 	return nil
@@ -1,8 +1,16 @@
 package commands
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/Disneyjr/dcm/internal/state"
 	"github.com/Disneyjr/dcm/internal/workspace"
 )
 
@@ -63,7 +71,402 @@ func TestResolveGroupServicesCycle(t *testing.T) {
 	}
 
 	_, _, err := resolveGroupServices(ws, "a", make(map[string]bool))
-	if err == nil {
-		t.Error("Expected error for cycle, got nil")
+	if !errors.Is(err, workspace.ErrInvalid) {
+		t.Errorf("Expected invalid config error for cycle, got %v", err)
+	}
+}
+
+func TestValidateWorkspace(t *testing.T) {
+	dir := t.TempDir()
+	ws := &workspace.Workspace{
+		Projects: map[string]workspace.Project{"api": {Path: dir}},
+		Groups:   map[string]workspace.Group{"dev": {Services: []string{"api"}}},
+	}
+	r, _ := dryRunner(ws)
+	if err := r.ValidateWorkspace(); err != nil {
+		t.Errorf("expected valid workspace, got %v", err)
+	}
+
+	ws.Groups["broken"] = workspace.Group{Services: []string{"missing"}, OnFailure: "explode"}
+	ws.Projects["api"] = workspace.Project{Path: dir, Tasks: map[string]workspace.Task{"migrate": {Service: "web"}}}
+	r, out := dryRunner(ws)
+	err := r.ValidateWorkspace()
+	if !errors.Is(err, workspace.ErrInvalid) || !strings.Contains(err.Error(), "3 problema(s)") {
+		t.Errorf("expected invalid config error with 3 problems, got %v", err)
+	}
+	if strings.Contains(out.String(), "Workspace válido") || !strings.Contains(out.String(), "tarefa 'migrate'") {
+		t.Errorf("unexpected validation output:\n%s", out)
+	}
+}
+
+// syncBuffer aceita escritas de várias goroutines, como o Out de um Runner.
+type syncBuffer struct {
+	mu sync.Mutex
+	b  strings.Builder
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.b.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.b.String()
+}
+
+// testRunner devolve um Runner que escreve tudo, inclusive o progresso, no buffer devolvido.
+func testRunner(ws *workspace.Workspace) (*Runner, *syncBuffer) {
+	out := &syncBuffer{}
+	r := NewRunner(ws)
+	r.Out, r.ErrOut = out, out
+	r.Reporter = NewConsoleReporter(out, false)
+	return r, out
+}
+
+// dryRunner é um testRunner em dry-run: os comandos são apenas impressos.
+func dryRunner(ws *workspace.Workspace) (*Runner, *syncBuffer) {
+	r, out := testRunner(ws)
+	r.DryRun = true
+	return r, out
+}
+
+func TestDownGroupReverseOrder(t *testing.T) {
+	parallelFalse := false
+	ws := &workspace.Workspace{
+		Projects: map[string]workspace.Project{
+			"database": {Path: "./database"},
+			"api":      {Path: "./api", DependsOn: []string{"database"}},
+			"frontend": {Path: "./frontend", DependsOn: []string{"api"}},
+		},
+		Groups: map[string]workspace.Group{
+			"dev": {Services: []string{"database", "frontend:web", "api"}, Parallel: &parallelFalse},
+		},
+	}
+
+	r, buf := dryRunner(ws)
+	if err := r.DownGroup(context.Background(), "dev", false); err != nil {
+		t.Errorf("DownGroup failed: %v", err)
+	}
+	out := buf.String()
+
+	frontend := strings.Index(out, "rm --stop --force web")
+	api := strings.Index(out, "cd ./api")
+	database := strings.Index(out, "cd ./database")
+	if frontend < 0 || api < 0 || database < 0 {
+		t.Fatalf("missing commands in output:\n%s", out)
+	}
+	if !(frontend < api && api < database) {
+		t.Errorf("expected frontend, api, database shutdown order, got:\n%s", out)
+	}
+	if strings.Contains(out, "cd ./frontend && docker compose down") {
+		t.Errorf("expected only the web service to be removed from frontend:\n%s", out)
+	}
+}
+
+func TestDownGroupKeepsOutsideDependencies(t *testing.T) {
+	ws := &workspace.Workspace{
+		Projects: map[string]workspace.Project{
+			"database": {Path: "./database"},
+			"api":      {Path: "./api", DependsOn: []string{"database"}},
+			"frontend": {Path: "./frontend", DependsOn: []string{"api"}},
+		},
+	}
+
+	for _, parallel := range []bool{false, true} {
+		ws.Groups = map[string]workspace.Group{"web": {Services: []string{"frontend", "api"}, Parallel: &parallel}}
+		r, buf := dryRunner(ws)
+		if err := r.DownGroup(context.Background(), "web", false); err != nil {
+			t.Errorf("DownGroup failed: %v", err)
+		}
+		out := buf.String()
+
+		frontend := strings.Index(out, "cd ./frontend")
+		api := strings.Index(out, "cd ./api")
+		if frontend < 0 || api < 0 || frontend > api {
+			t.Errorf("parallel=%v: expected frontend stopped before api, got:\n%s", parallel, out)
+		}
+		if strings.Contains(out, "cd ./database") {
+			t.Errorf("parallel=%v: expected the database outside the group to keep running:\n%s", parallel, out)
+		}
+	}
+
+	// Parar um projeto não para as suas dependências
+	r, buf := dryRunner(ws)
+	if err := r.DownGroup(context.Background(), "frontend", false); err != nil {
+		t.Errorf("DownGroup failed: %v", err)
+	}
+	if out := buf.String(); !strings.Contains(out, "cd ./frontend") || strings.Contains(out, "cd ./api") || strings.Contains(out, "cd ./database") {
+		t.Errorf("expected only frontend to be stopped, got:\n%s", out)
+	}
+}
+
+func TestBuildListResult(t *testing.T) {
+	ws := &workspace.Workspace{
+		Projects: map[string]workspace.Project{
+			"db":  {Path: "/srv/db", Description: "Database"},
+			"api": {Path: "/srv/api"},
+		},
+		Groups: map[string]workspace.Group{
+			"infra": {Services: []string{"db"}},
+			"full":  {Extends: "infra", Services: []string{"api:web"}},
+		},
+	}
+
+	result := buildListResult(ws)
+	if len(result.Projects) != 2 || result.Projects[0].Name != "api" || result.Projects[1].Name != "db" {
+		t.Fatalf("unexpected projects: %+v", result.Projects)
+	}
+	if groups := result.Projects[1].Groups; len(groups) != 2 || groups[0] != "full" || groups[1] != "infra" {
+		t.Errorf("expected db in groups full and infra (via extends), got %v", groups)
+	}
+	if services := result.Groups[0].Services; result.Groups[0].Name != "full" || len(services) != 2 {
+		t.Errorf("unexpected resolved services for full: %+v", result.Groups[0])
+	}
+
+	var buf strings.Builder
+	if err := writeStructured(&buf, OutputJSON, result); err != nil {
+		t.Fatalf("writeStructured failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"description": "Database"`) {
+		t.Errorf("unexpected json output:\n%s", buf.String())
+	}
+}
+
+func TestResolveTargets(t *testing.T) {
+	parallelFalse := false
+	ws := &workspace.Workspace{
+		Projects: map[string]workspace.Project{
+			"p1": {Path: "./p1"},
+			"p2": {Path: "./p2"},
+		},
+		Groups: map[string]workspace.Group{
+			"base": {Services: []string{"p1"}, Parallel: &parallelFalse},
+			"full": {Extends: "base", Services: []string{"p2:web"}},
+		},
+	}
+
+	services, parallel, err := resolveTargets(ws, "full")
+	if err != nil || len(services) != 2 || parallel {
+		t.Errorf("unexpected group resolution: %v %v %v", services, parallel, err)
+	}
+
+	services, _, err = resolveTargets(ws, "p2:web")
+	if err != nil || len(services) != 1 || services[0] != "p2:web" {
+		t.Errorf("unexpected spec resolution: %v %v", services, err)
+	}
+
+	services, parallel, err = resolveTargets(ws, "")
+	if err != nil || len(services) != 2 || parallel {
+		t.Errorf("unexpected workspace resolution: %v %v %v", services, parallel, err)
+	}
+
+	if _, _, err := resolveTargets(ws, "missing"); err == nil {
+		t.Error("expected error for unknown target, got nil")
+	}
+}
+
+func TestRestartGroupDryRun(t *testing.T) {
+	ws := &workspace.Workspace{
+		Projects: map[string]workspace.Project{
+			"db":    {Path: "./db"},
+			"cache": {Path: "./cache", DependsOn: []string{"db"}},
+			"api":   {Path: "./api", DependsOn: []string{"cache"}},
+		},
+		Groups: map[string]workspace.Group{
+			"dev":  {Services: []string{"api:web"}},
+			"full": {Services: []string{"api:web", "db"}},
+		},
+	}
+
+	// As dependências de fora do alvo não são reiniciadas
+	r, buf := dryRunner(ws)
+	if err := r.RestartGroup(context.Background(), "dev"); err != nil {
+		t.Errorf("RestartGroup failed: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "cd ./api && docker compose restart web\n") || strings.Contains(out, "cd ./db") || strings.Contains(out, "cd ./cache") {
+		t.Errorf("expected only api:web to be restarted, got:\n%s", out)
+	}
+
+	// Dentro do alvo, a ordem das dependências continua valendo, mesmo através de um projeto de fora
+	r, buf = dryRunner(ws)
+	if err := r.RestartGroup(context.Background(), "full"); err != nil {
+		t.Errorf("RestartGroup failed: %v", err)
+	}
+	out = buf.String()
+	db := strings.Index(out, "cd ./db && docker compose restart\n")
+	api := strings.Index(out, "cd ./api && docker compose restart web\n")
+	if db < 0 || api < 0 || db > api || strings.Contains(out, "cd ./cache") {
+		t.Errorf("expected db restarted before api:web and cache left alone, got:\n%s", out)
+	}
+}
+
+func TestRunParallelLimit(t *testing.T) {
+	specs := []string{"a", "b", "c", "d", "e", "f"}
+	var running, peak int32
+
+	errs := runParallel(specs, 2, func(spec string) error {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		// Os primeiros specs demoram mais, para que terminem fora de ordem
+		time.Sleep(time.Duration(len(specs)-strings.Index("abcdef", spec)) * 5 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+
+		if spec == "b" || spec == "e" {
+			return fmt.Errorf("falha em %s", spec)
+		}
+		return nil
+	})
+
+	if peak > 2 {
+		t.Errorf("expected at most 2 concurrent runs, got %d", peak)
+	}
+	if len(errs) != len(specs) {
+		t.Fatalf("expected %d results, got %d", len(specs), len(errs))
+	}
+	for i, err := range errs {
+		failed := specs[i] == "b" || specs[i] == "e"
+		if (err != nil) != failed {
+			t.Errorf("unexpected result for %s: %v", specs[i], err)
+		}
+		if failed && err.Error() != "falha em "+specs[i] {
+			t.Errorf("error reported out of order for %s: %v", specs[i], err)
+		}
+	}
+}
+
+func TestConcurrencyLimit(t *testing.T) {
+	ws := &workspace.Workspace{
+		Groups: map[string]workspace.Group{
+			"base":     {Services: []string{"p1"}, MaxParallel: 3},
+			"extended": {Extends: "base"},
+			"own":      {Extends: "base", MaxParallel: 1},
+		},
+	}
+
+	cases := map[string]int{"base": 3, "extended": 3, "own": 1, "p1": 0}
+	for target, expected := range cases {
+		if got := concurrencyLimit(ws, target, 0); got != expected {
+			t.Errorf("concurrencyLimit(%s) = %d, expected %d", target, got, expected)
+		}
+	}
+
+	// --jobs tem precedência sobre o grupo
+	if got := concurrencyLimit(ws, "own", 5); got != 5 {
+		t.Errorf("expected --jobs to win, got %d", got)
+	}
+}
+
+func TestUpGroupOnFailure(t *testing.T) {
+	parallelFalse := false
+	newWorkspace := func(policy string) *workspace.Workspace {
+		return &workspace.Workspace{
+			Projects: map[string]workspace.Project{
+				"db":     {Path: "./db"},
+				"broken": {Path: "./broken", Readiness: &workspace.Readiness{Type: "invalid"}},
+				"api":    {Path: "./api"},
+			},
+			Groups: map[string]workspace.Group{
+				"dev": {Services: []string{"db", "broken", "api"}, Parallel: &parallelFalse, OnFailure: policy},
+			},
+		}
+	}
+
+	// continue (padrão): api ainda é iniciado e o erro indica uma falha parcial
+	r, out := dryRunner(newWorkspace(""))
+	if err := r.UpGroup(context.Background(), "dev"); !errors.Is(err, ErrPartialFailure) {
+		t.Errorf("expected partial failure with onFailure=continue, got %v", err)
+	}
+	if !strings.Contains(out.String(), "cd ./api && docker compose up -d") {
+		t.Errorf("expected api to start with onFailure=continue:\n%s", out)
+	}
+
+	// stop: nada é iniciado depois da falha
+	r, out = dryRunner(newWorkspace(workspace.OnFailureStop))
+	if err := r.UpGroup(context.Background(), "dev"); err == nil {
+		t.Error("expected error with onFailure=stop")
+	}
+	if strings.Contains(out.String(), "cd ./api && docker compose up -d") || !strings.Contains(out.String(), "não iniciados: api") {
+		t.Errorf("expected api not to start with onFailure=stop:\n%s", out)
+	}
+
+	// rollback: o que esta execução iniciou é parado, em ordem inversa
+	r, out = dryRunner(newWorkspace(workspace.OnFailureRollback))
+	// db deu certo antes da falha, mas foi desfeito: a falha é total (e não parcial, código 6)
+	err := r.UpGroup(context.Background(), "dev")
+	if !errors.Is(err, ErrComposeFailed) || errors.Is(err, ErrPartialFailure) {
+		t.Errorf("expected a total failure with onFailure=rollback, got %v", err)
+	}
+	if result := runResult(err); result != state.ResultFailed {
+		t.Errorf("expected the run to be recorded as failed, got %s", result)
+	}
+	if !strings.Contains(out.String(), "cd ./db && docker compose down") || !strings.Contains(out.String(), "cd ./broken && docker compose down") {
+		t.Errorf("expected started projects to be brought down:\n%s", out)
+	}
+	if strings.Contains(out.String(), "cd ./api") {
+		t.Errorf("expected api to be left untouched:\n%s", out)
+	}
+
+	// --fail-fast transforma continue em stop
+	if policy := failurePolicy(newWorkspace(""), "dev", true); policy != workspace.OnFailureStop {
+		t.Errorf("expected --fail-fast to stop, got %s", policy)
+	}
+	if policy := failurePolicy(newWorkspace(workspace.OnFailureRollback), "dev", true); policy != workspace.OnFailureRollback {
+		t.Errorf("expected --fail-fast to keep rollback, got %s", policy)
+	}
+}
+
+func TestExecutePlanStopParallel(t *testing.T) {
+	plan := &startupPlan{
+		Order: []string{"a", "b", "c", "d"},
+		Waves: [][]string{{"a", "b", "c"}, {"d"}},
+	}
+
+	var ran []string
+	var mu sync.Mutex
+	r, _ := dryRunner(&workspace.Workspace{})
+	result := r.executePlan(context.Background(), &state.Run{Command: "up"}, plan, planOptions{Parallel: true, Limit: 1, StopOnFailure: true}, func(spec string) error {
+		mu.Lock()
+		ran = append(ran, spec)
+		mu.Unlock()
+		if spec == "a" {
+			return fmt.Errorf("falhou")
+		}
+		return nil
+	})
+	if strings.Join(result.Failed, ",") != "a" || strings.Join(result.NotStarted, ",") != "b,c,d" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	// Com um único worker, a falha de "a" impede o início de todo o resto
+	if strings.Join(ran, ",") != "a" {
+		t.Errorf("expected only a to run, got %v", ran)
+	}
+}
+
+func TestExecutePlanCanceled(t *testing.T) {
+	plan := &startupPlan{Order: []string{"a", "b"}, Waves: [][]string{{"a"}, {"b"}}}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var ran []string
+	r, _ := dryRunner(&workspace.Workspace{})
+	result := r.executePlan(ctx, &state.Run{Command: "up"}, plan, planOptions{}, func(spec string) error {
+		ran = append(ran, spec)
+		cancel()
+		return nil
+	})
+	if strings.Join(result.NotStarted, ",") != "b" {
+		t.Errorf("expected b not to start after cancel, got %+v", result)
+	}
+	if strings.Join(ran, ",") != "a" {
+		t.Errorf("expected only a to run, got %v", ran)
 	}
 }
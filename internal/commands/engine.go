@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Disneyjr/dcm/internal/i18n"
+	"github.com/Disneyjr/dcm/internal/workspace"
+)
+
+// EngineEnvVar permite forçar o engine sem alterar o workspace.json.
+const EngineEnvVar = "DCM_ENGINE"
+
+// Engine descreve o binário usado para executar os comandos compose.
+// Args são inseridos antes dos argumentos de cada comando (ex: "compose" em "docker compose").
+type Engine struct {
+	Name    string
+	Command string
+	Args    []string
+}
+
+func (e Engine) String() string {
+	return strings.Join(append([]string{e.Command}, e.Args...), " ")
+}
+
+// commandArgs monta os argumentos completos para uma invocação do engine.
+func (e Engine) commandArgs(args []string) []string {
+	full := make([]string, 0, len(e.Args)+len(args))
+	full = append(full, e.Args...)
+	return append(full, args...)
+}
+
+// Ordem de preferência usada na detecção automática.
+var knownEngines = []Engine{
+	{Name: "docker", Command: "docker", Args: []string{"compose"}},
+	{Name: "docker-compose", Command: "docker-compose"},
+	{Name: "podman-compose", Command: "podman-compose"},
+	{Name: "nerdctl", Command: "nerdctl", Args: []string{"compose"}},
+}
+
+// DefaultEngine é o engine de um Runner novo: Compose v2 (docker compose).
+var DefaultEngine = knownEngines[0]
+
+// engineAvailable é substituível nos testes para não depender dos binários instalados.
+var engineAvailable = func(e Engine) bool {
+	if _, err := exec.LookPath(e.Command); err != nil {
+		return false
+	}
+	if len(e.Args) == 0 {
+		return true
+	}
+	// "docker" pode existir sem o plugin compose, então confirmamos executando "version"
+	return exec.Command(e.Command, e.commandArgs([]string{"version"})...).Run() == nil
+}
+
+// LookupEngine retorna o engine conhecido com o nome informado.
+func LookupEngine(name string) (Engine, error) {
+	for _, e := range knownEngines {
+		if e.Name == name {
+			return e, nil
+		}
+	}
+
+	names := make([]string, len(knownEngines))
+	for i, e := range knownEngines {
+		names[i] = e.Name
+	}
+	return Engine{}, i18n.Errorf("engine.unknown", name, strings.Join(names, ", "))
+}
+
+// ResolveEngine escolhe o engine na ordem: variável DCM_ENGINE, campo "engine"
+// do workspace e, por fim, o primeiro engine disponível no PATH.
+func ResolveEngine(ws *workspace.Workspace) (Engine, error) {
+	if name := os.Getenv(EngineEnvVar); name != "" {
+		return LookupEngine(name)
+	}
+	if ws != nil && ws.Engine != "" {
+		return LookupEngine(ws.Engine)
+	}
+
+	for _, e := range knownEngines {
+		if engineAvailable(e) {
+			return e, nil
+		}
+	}
+	return Engine{}, i18n.Errorf("engine.none")
+}
+
+// composeArgs monta a invocação completa do engine para um projeto, inserindo as opções globais
+// do compose (-f, -p, --profile, --env-file) antes do subcomando.
+func (e Engine) composeArgs(project workspace.Project, args []string) []string {
+	var options []string
+	for _, file := range project.ComposeFiles {
+		options = append(options, "-f", file)
+	}
+	if project.ProjectName != "" {
+		options = append(options, "-p", project.ProjectName)
+	}
+	for _, profile := range project.Profiles {
+		options = append(options, "--profile", profile)
+	}
+	if project.EnvFile != "" {
+		options = append(options, "--env-file", project.EnvFile)
+	}
+
+	return e.commandArgs(append(options, args...))
+}
+
+var composeProjectNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// validateComposeOptions confere os arquivos referenciados pelo projeto e o formato do nome
+// exigido pelo compose.
+func validateComposeOptions(project workspace.Project) []error {
+	var errs []error
+
+	relative := func(file string) string {
+		if filepath.IsAbs(file) {
+			return file
+		}
+		return filepath.Join(project.Path, file)
+	}
+
+	for _, file := range project.ComposeFiles {
+		if _, err := os.Stat(relative(file)); err != nil {
+			errs = append(errs, i18n.Errorf("engine.compose_file_not_found", file))
+		}
+	}
+	if project.EnvFile != "" {
+		if _, err := os.Stat(relative(project.EnvFile)); err != nil {
+			errs = append(errs, i18n.Errorf("engine.env_file_not_found", project.EnvFile))
+		}
+	}
+	if project.ProjectName != "" && !composeProjectNamePattern.MatchString(project.ProjectName) {
+		errs = append(errs, i18n.Errorf("engine.invalid_project_name", project.ProjectName))
+	}
+	for _, profile := range project.Profiles {
+		if strings.TrimSpace(profile) == "" {
+			errs = append(errs, i18n.Errorf("engine.empty_profile"))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Disneyjr/dcm/internal/workspace"
+)
+
+func TestResolveEngine(t *testing.T) {
+	original := engineAvailable
+	defer func() { engineAvailable = original }()
+
+	// Apenas podman-compose "instalado"
+	engineAvailable = func(e Engine) bool { return e.Name == "podman-compose" }
+
+	t.Setenv(EngineEnvVar, "")
+	engine, err := ResolveEngine(&workspace.Workspace{})
+	if err != nil {
+		t.Fatalf("auto-detection failed: %v", err)
+	}
+	if engine.Name != "podman-compose" {
+		t.Errorf("expected podman-compose, got %s", engine.Name)
+	}
+
+	// Campo do workspace tem precedência sobre a detecção
+	engine, err = ResolveEngine(&workspace.Workspace{Engine: "nerdctl"})
+	if err != nil {
+		t.Fatalf("workspace engine failed: %v", err)
+	}
+	if engine.String() != "nerdctl compose" {
+		t.Errorf("unexpected engine command: %s", engine)
+	}
+
+	// Variável de ambiente tem precedência sobre o workspace
+	t.Setenv(EngineEnvVar, "docker-compose")
+	engine, err = ResolveEngine(&workspace.Workspace{Engine: "nerdctl"})
+	if err != nil {
+		t.Fatalf("env engine failed: %v", err)
+	}
+	if engine.Name != "docker-compose" {
+		t.Errorf("expected docker-compose, got %s", engine.Name)
+	}
+
+	t.Setenv(EngineEnvVar, "invalid")
+	if _, err := ResolveEngine(nil); err == nil {
+		t.Error("expected error for unknown engine, got nil")
+	}
+}
+
+func TestResolveEngineNoneAvailable(t *testing.T) {
+	original := engineAvailable
+	defer func() { engineAvailable = original }()
+	engineAvailable = func(Engine) bool { return false }
+
+	t.Setenv(EngineEnvVar, "")
+	if _, err := ResolveEngine(&workspace.Workspace{}); err == nil {
+		t.Error("expected error when no engine is available, got nil")
+	}
+}
+
+func TestComposeArgs(t *testing.T) {
+	project := workspace.Project{
+		Path:         "./api",
+		ComposeFiles: []string{"compose.yml", "compose.dev.yml"},
+		ProjectName:  "api-dev",
+		Profiles:     []string{"debug"},
+		EnvFile:      ".env.dev",
+	}
+
+	args := DefaultEngine.composeArgs(project, []string{"up", "-d"})
+	expected := "compose -f compose.yml -f compose.dev.yml -p api-dev --profile debug --env-file .env.dev up -d"
+	if strings.Join(args, " ") != expected {
+		t.Errorf("unexpected args: %v", args)
+	}
+}
+
+func TestValidateComposeOptions(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "compose.yml"), []byte("services: {}"), 0644)
+
+	valid := workspace.Project{Path: dir, ComposeFiles: []string{"compose.yml"}, ProjectName: "api_dev-1"}
+	if errs := validateComposeOptions(valid); len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+
+	invalid := workspace.Project{Path: dir, ComposeFiles: []string{"missing.yml"}, ProjectName: "API Dev", EnvFile: ".env.missing"}
+	if errs := validateComposeOptions(invalid); len(errs) != 3 {
+		t.Errorf("expected 3 errors, got %v", errs)
+	}
+}
@@ -0,0 +1,21 @@
+package commands
+
+import (
+	"github.com/Disneyjr/dcm/internal/errclass"
+	"github.com/Disneyjr/dcm/internal/i18n"
+)
+
+// Classes de erro das operações sobre os serviços, identificáveis com errors.Is
+// (o CLI usa para escolher o código de saída).
+var (
+	ErrComposeFailed  error = i18n.Message("errors.compose_failed")
+	ErrPartialFailure error = i18n.Message("errors.partial_failure")
+)
+
+// groupFailure classifica a falha de uma operação sobre vários specs: parcial se algum deu certo.
+func groupFailure(succeeded int, err error) error {
+	if succeeded > 0 {
+		return errclass.Wrap(ErrPartialFailure, err)
+	}
+	return errclass.Wrap(ErrComposeFailed, err)
+}
@@ -0,0 +1,246 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"github.com/Disneyjr/dcm/internal/i18n"
+	"github.com/Disneyjr/dcm/internal/workspace"
+	"github.com/Disneyjr/dcm/utils"
+	"github.com/Disneyjr/dcm/utils/terminal"
+)
+
+// ExecOptions liga um comando avulso (exec, run e tarefas) aos streams de quem chamou. Writers nil
+// usam Out e ErrOut do Runner; Stdin nil deixa o comando sem entrada.
+type ExecOptions struct {
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// tty indica se o comando roda em um terminal de verdade: só então o compose aloca um TTY no
+// container. Com pipes ou arquivos ele recebe -T, senão a saída viria com \r e sequências de controle.
+func (o ExecOptions) tty() bool {
+	in, ok := o.Stdin.(*os.File)
+	if !ok || !terminal.IsTerminal(in) {
+		return false
+	}
+	out, ok := o.Stdout.(*os.File)
+	return ok && terminal.IsTerminal(out)
+}
+
+// ExitError indica que o comando executado no container terminou com um código diferente de zero.
+// O CLI sai com o mesmo código, como se o comando tivesse rodado direto no terminal.
+type ExitError struct {
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return i18n.T("exec.exit_code", e.Code)
+}
+
+// Exec executa command em um container que já está rodando ("compose exec"). spec é
+// "projeto:serviço"; o serviço pode ser omitido quando o projeto tem um só.
+func (r *Runner) Exec(ctx context.Context, spec string, command []string, opts ExecOptions) error {
+	return r.execSpec(ctx, spec, []string{"exec"}, command, opts)
+}
+
+// RunOnce executa command em um container novo, removido ao terminar ("compose run --rm").
+func (r *Runner) RunOnce(ctx context.Context, spec string, command []string, opts ExecOptions) error {
+	return r.execSpec(ctx, spec, []string{"run", "--rm"}, command, opts)
+}
+
+// RunTask executa uma tarefa do projeto, com extraArgs acrescentados ao final do comando.
+func (r *Runner) RunTask(ctx context.Context, projectName, taskName string, extraArgs []string, opts ExecOptions) error {
+	project, exists := r.Workspace.Projects[projectName]
+	if !exists {
+		return i18n.Errorf("workspace.project_not_found", projectName)
+	}
+	task, exists := project.Tasks[taskName]
+	if !exists {
+		if len(project.Tasks) == 0 {
+			return i18n.Errorf("task.none_in_project", projectName)
+		}
+		return i18n.Errorf("task.not_found", taskName, projectName, strings.Join(sortedTaskNames(project), ", "))
+	}
+	if err := task.Validate(); err != nil {
+		return i18n.Errorf("validate.task_error", projectName, taskName, err)
+	}
+
+	subcommand := []string{"exec"}
+	if task.Run {
+		subcommand = []string{"run", "--rm"}
+	}
+	command := append(append([]string{}, task.Command...), extraArgs...)
+	return r.execCommand(ctx, project, task.Service, subcommand, command, opts)
+}
+
+func (r *Runner) execSpec(ctx context.Context, spec string, subcommand, command []string, opts ExecOptions) error {
+	if len(command) == 0 {
+		return i18n.Errorf("exec.missing_command")
+	}
+	projectName, service := splitServiceSpec(spec)
+	project, exists := r.Workspace.Projects[projectName]
+	if !exists {
+		return i18n.Errorf("workspace.project_not_found", projectName)
+	}
+	if service == "" {
+		var err error
+		if service, err = singleService(project, projectName); err != nil {
+			return err
+		}
+	}
+	return r.execCommand(ctx, project, service, subcommand, command, opts)
+}
+
+// singleService escolhe o serviço quando o spec traz só o projeto: isso só é possível se ele tiver
+// um único serviço.
+func singleService(project workspace.Project, projectName string) (string, error) {
+	services, err := ComposeServices(project)
+	if err != nil {
+		return "", err
+	}
+	switch len(services) {
+	case 0:
+		return "", i18n.Errorf("exec.service_required", projectName)
+	case 1:
+		return services[0], nil
+	}
+	return "", i18n.Errorf("exec.service_choices", projectName, strings.Join(services, ", "))
+}
+
+// execCommand executa o comando do compose ligado direto aos streams de opts. Ao contrário dos
+// demais comandos, o processo fica no grupo do terminal: Ctrl+C e o TTY chegam ao container como se
+// o compose tivesse sido chamado à mão, e o dcm só espera ele terminar.
+func (r *Runner) execCommand(ctx context.Context, project workspace.Project, service string, subcommand, command []string, opts ExecOptions) error {
+	if err := interruption(ctx); err != nil {
+		return err
+	}
+
+	args := append([]string{}, subcommand...)
+	if !opts.tty() {
+		args = append(args, "-T")
+	}
+	args = append(append(args, service), command...)
+	args = r.Engine.composeArgs(project, args)
+
+	if r.DryRun {
+		r.printf("%s[DRY-RUN] cd %s && %s %s\n", utils.Icon("yellow", "🛠️"), project.Path, r.Engine.Command, strings.Join(args, " "))
+		return nil
+	}
+
+	c := exec.Command(r.Engine.Command, args...)
+	c.Dir = project.Path
+	c.Stdin, c.Stdout, c.Stderr = opts.Stdin, opts.Stdout, opts.Stderr
+	if c.Stdout == nil {
+		c.Stdout = r.Out
+	}
+	if c.Stderr == nil {
+		c.Stderr = r.ErrOut
+	}
+	// A saída padrão é do comando (pode estar indo para um pipe), então a linha vai para o stderr
+	if r.Verbose {
+		fmt.Fprintf(c.Stderr, "%s cd %s && %s %s\n", utils.Colorize("magenta", "$"), project.Path, r.Engine.Command, strings.Join(args, " "))
+	}
+
+	err := c.Run()
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &exitErr) && exitErr.ExitCode() >= 0:
+		return &ExitError{Code: exitErr.ExitCode()}
+	case errors.As(err, &exitErr):
+		// Encerrado por um sinal: o Ctrl+C do usuário ou outro processo
+		if err := interruption(ctx); err != nil {
+			return err
+		}
+		return &ExitError{Code: 1}
+	}
+	return i18n.Errorf("exec.error", err)
+}
+
+// TaskInfo descreve uma tarefa na saída estruturada do "dcm task".
+type TaskInfo struct {
+	Project     string   `json:"project" yaml:"project"`
+	Name        string   `json:"name" yaml:"name"`
+	Service     string   `json:"service" yaml:"service"`
+	Command     []string `json:"command" yaml:"command"`
+	Run         bool     `json:"run,omitempty" yaml:"run,omitempty"`
+	Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+type TasksResult struct {
+	Tasks []TaskInfo `json:"tasks" yaml:"tasks"`
+}
+
+// BuildTasksResult lista as tarefas de um projeto ou, com projectName vazio, de todo o workspace.
+func BuildTasksResult(ws *workspace.Workspace, projectName string) (TasksResult, error) {
+	projects := sortedProjectNames(ws)
+	if projectName != "" {
+		if _, exists := ws.Projects[projectName]; !exists {
+			return TasksResult{}, i18n.Errorf("workspace.project_not_found", projectName)
+		}
+		projects = []string{projectName}
+	}
+
+	result := TasksResult{Tasks: []TaskInfo{}}
+	for _, name := range projects {
+		project := ws.Projects[name]
+		for _, taskName := range sortedTaskNames(project) {
+			task := project.Tasks[taskName]
+			result.Tasks = append(result.Tasks, TaskInfo{
+				Project:     name,
+				Name:        taskName,
+				Service:     task.Service,
+				Command:     task.Command,
+				Run:         task.Run,
+				Description: task.Description,
+			})
+		}
+	}
+	return result, nil
+}
+
+// ListTasks mostra as tarefas de um projeto ou, com projectName vazio, de todo o workspace.
+func (r *Runner) ListTasks(projectName string) error {
+	result, err := BuildTasksResult(r.Workspace, projectName)
+	if err != nil {
+		return err
+	}
+	if r.structuredOutput() {
+		return r.printStructured(result)
+	}
+
+	if len(result.Tasks) == 0 {
+		r.printf("%s%s\n", utils.Icon("yellow", "⚠️"), i18n.T("task.none"))
+		return nil
+	}
+	r.printf("%s%s\n", utils.Icon("cyan", "📌"), i18n.T("task.title"))
+	for _, task := range result.Tasks {
+		name := task.Project + " " + task.Name
+		if task.Description != "" {
+			r.printf("  - %s: %s\n", utils.Colorize("blue", name), task.Description)
+		} else {
+			r.printf("  - %s\n", utils.Colorize("blue", name))
+		}
+		r.printf("    %s\n", utils.Colorize("magenta", task.Service+"$ "+strings.Join(task.Command, " ")))
+	}
+	r.printf("\n")
+	return nil
+}
+
+func sortedTaskNames(project workspace.Project) []string {
+	names := make([]string, 0, len(project.Tasks))
+	for name := range project.Tasks {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
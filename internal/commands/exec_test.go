@@ -0,0 +1,117 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/Disneyjr/dcm/internal/workspace"
+)
+
+func execWorkspace(t *testing.T) *workspace.Workspace {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "compose.yaml"), []byte("services:\n  web: {}\n  worker: {}\n"), 0644)
+	return &workspace.Workspace{
+		Projects: map[string]workspace.Project{
+			"api": {
+				Path: dir,
+				Tasks: map[string]workspace.Task{
+					"migrate": {Service: "web", Command: []string{"npm", "run", "migrate"}},
+					"seed":    {Service: "worker", Command: []string{"./seed.sh"}, Run: true},
+				},
+			},
+			"db": {Path: "./db", Services: map[string]workspace.ServiceOptions{"postgres": {}}},
+		},
+	}
+}
+
+func TestExecDryRun(t *testing.T) {
+	r, out := dryRunner(execWorkspace(t))
+	ctx := context.Background()
+
+	if err := r.Exec(ctx, "api:web", []string{"sh", "-c", "ls"}, ExecOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	// Sem terminal o compose recebe -T; com o serviço omitido vale o único do projeto
+	if err := r.RunOnce(ctx, "db", []string{"psql"}, ExecOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.RunTask(ctx, "api", "seed", []string{"--reset"}, ExecOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{
+		"docker compose exec -T web sh -c ls\n",
+		"docker compose run --rm -T postgres psql\n",
+		"docker compose run --rm -T worker ./seed.sh --reset\n",
+	} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("expected %q in the output:\n%s", expected, out.String())
+		}
+	}
+}
+
+func TestExecErrors(t *testing.T) {
+	r, _ := dryRunner(execWorkspace(t))
+	ctx := context.Background()
+
+	cases := []struct {
+		err      error
+		expected string
+	}{
+		{r.Exec(ctx, "api", []string{"ls"}, ExecOptions{}), "web, worker"},
+		{r.Exec(ctx, "nope:web", []string{"ls"}, ExecOptions{}), "nope"},
+		{r.Exec(ctx, "api:web", nil, ExecOptions{}), "--"},
+		{r.RunTask(ctx, "api", "deploy", nil, ExecOptions{}), "migrate, seed"},
+		{r.RunTask(ctx, "db", "migrate", nil, ExecOptions{}), "db"},
+	}
+	for i, c := range cases {
+		if c.err == nil || !strings.Contains(c.err.Error(), c.expected) {
+			t.Errorf("case %d: expected an error mentioning %q, got %v", i, c.expected, c.err)
+		}
+	}
+}
+
+func TestExecExitCode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("usa sh")
+	}
+
+	r, _ := testRunner(execWorkspace(t))
+	// O "engine" repete a entrada e os argumentos e termina com 3, como um comando que falhou no container
+	r.Engine = Engine{Command: "sh", Args: []string{"-c", `cat; echo "$@"; exit 3`, "sh"}}
+
+	var stdout strings.Builder
+	err := r.Exec(context.Background(), "api:web", []string{"ls"}, ExecOptions{Stdin: strings.NewReader("entrada\n"), Stdout: &stdout})
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != 3 {
+		t.Fatalf("expected exit code 3, got %v", err)
+	}
+	if stdout.String() != "entrada\nexec -T web ls\n" {
+		t.Errorf("unexpected output: %q", stdout.String())
+	}
+}
+
+func TestListTasks(t *testing.T) {
+	r, out := testRunner(execWorkspace(t))
+	if err := r.ListTasks(""); err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{"api migrate", "web$ npm run migrate", "api seed"} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("expected %q in the output:\n%s", expected, out.String())
+		}
+	}
+
+	result, err := BuildTasksResult(r.Workspace, "db")
+	if err != nil || len(result.Tasks) != 0 {
+		t.Errorf("expected no tasks for db, got %v, %v", result, err)
+	}
+	if _, err := BuildTasksResult(r.Workspace, "nope"); err == nil {
+		t.Error("expected error for an unknown project")
+	}
+}
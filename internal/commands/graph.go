@@ -0,0 +1,259 @@
+package commands
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Disneyjr/dcm/internal/i18n"
+	"github.com/Disneyjr/dcm/internal/workspace"
+)
+
+// startupPlan é a ordem de inicialização calculada a partir do dependsOn dos projetos.
+// Order é uma ordenação topológica estável (respeita a ordem do grupo sempre que possível)
+// e Waves agrupa os serviços que podem subir juntos, já que suas dependências estão nas etapas anteriores.
+type startupPlan struct {
+	Order   []string
+	Waves   [][]string
+	Deps    map[string][]string
+	Targets []string // Os specs pedidos, sem as dependências que o grafo acrescentou
+}
+
+func splitServiceSpec(spec string) (string, string) {
+	parts := strings.SplitN(spec, ":", 2)
+	if len(parts) > 1 {
+		return parts[0], parts[1]
+	}
+	return parts[0], ""
+}
+
+// specDependencies retorna as dependências declaradas para um spec. Para um projeto inteiro
+// as dependências de todos os seus serviços são incluídas, exceto as internas ao próprio projeto
+// (essas o compose já resolve sozinho).
+func specDependencies(project workspace.Project, projectName, targetService string) []string {
+	deps := append([]string{}, project.DependsOn...)
+	if targetService != "" {
+		return append(deps, project.Services[targetService].DependsOn...)
+	}
+
+	names := make([]string, 0, len(project.Services))
+	for name := range project.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, dep := range project.Services[name].DependsOn {
+			if depProject, _ := splitServiceSpec(dep); depProject != projectName {
+				deps = append(deps, dep)
+			}
+		}
+	}
+	return deps
+}
+
+// planStartup monta o grafo de dependências dos specs (incluindo dependências transitivas
+// que não estão no grupo) e calcula a ordem de inicialização.
+func planStartup(ws *workspace.Workspace, specs []string) (*startupPlan, error) {
+	wholeProjects := make(map[string]bool)
+	for _, spec := range specs {
+		if projectName, targetService := splitServiceSpec(spec); targetService == "" {
+			wholeProjects[projectName] = true
+		}
+	}
+
+	// Um projeto inteiro pode entrar só pelo dependsOn: o grafo é refeito até que todo serviço de um
+	// projeto que sobe inteiro seja absorvido por ele, senão os dois rodariam o compose juntos
+	for {
+		deps, roots, err := expandGraph(ws, specs, wholeProjects)
+		if err != nil {
+			return nil, err
+		}
+
+		expanded := false
+		for spec := range deps {
+			if projectName, targetService := splitServiceSpec(spec); targetService == "" && !wholeProjects[projectName] {
+				wholeProjects[projectName] = true
+				expanded = true
+			}
+		}
+		if expanded {
+			continue
+		}
+
+		order, err := topologicalOrder(roots, deps)
+		if err != nil {
+			return nil, err
+		}
+		return &startupPlan{Order: order, Waves: planWaves(order, deps), Deps: deps, Targets: roots}, nil
+	}
+}
+
+// expandGraph acrescenta aos specs suas dependências transitivas. Um serviço de um projeto em
+// wholeProjects é satisfeito pelo próprio projeto.
+func expandGraph(ws *workspace.Workspace, specs []string, wholeProjects map[string]bool) (map[string][]string, []string, error) {
+	canonical := func(spec string) string {
+		if projectName, _ := splitServiceSpec(spec); wholeProjects[projectName] {
+			return projectName
+		}
+		return spec
+	}
+
+	deps := make(map[string][]string)
+	var roots []string
+
+	var add func(spec string) error
+	add = func(spec string) error {
+		if _, seen := deps[spec]; seen {
+			return nil
+		}
+
+		projectName, targetService := splitServiceSpec(spec)
+		project, exists := ws.Projects[projectName]
+		if !exists {
+			return i18n.Errorf("workspace.project_not_found", projectName)
+		}
+
+		deps[spec] = []string{}
+		var resolved []string
+		for _, dep := range specDependencies(project, projectName, targetService) {
+			dep = canonical(dep)
+			if containsString(resolved, dep) {
+				continue
+			}
+			resolved = append(resolved, dep)
+			if err := add(dep); err != nil {
+				return i18n.Errorf("graph.dependency_of", spec, err)
+			}
+		}
+		deps[spec] = resolved
+		return nil
+	}
+
+	for _, spec := range specs {
+		spec = canonical(spec)
+		if containsString(roots, spec) {
+			continue
+		}
+		roots = append(roots, spec)
+		if err := add(spec); err != nil {
+			return nil, nil, err
+		}
+	}
+	return deps, roots, nil
+}
+
+// planWaves agrupa os specs em etapas: cada um fica na primeira etapa depois de todas as suas
+// dependências.
+func planWaves(order []string, deps map[string][]string) [][]string {
+	level := make(map[string]int)
+	maxLevel := 0
+	for _, spec := range order {
+		for _, dep := range deps[spec] {
+			if level[dep]+1 > level[spec] {
+				level[spec] = level[dep] + 1
+			}
+		}
+		if level[spec] > maxLevel {
+			maxLevel = level[spec]
+		}
+	}
+
+	waves := make([][]string, maxLevel+1)
+	for _, spec := range order {
+		waves[level[spec]] = append(waves[level[spec]], spec)
+	}
+	return waves
+}
+
+// targetsOnly devolve o plano apenas com os specs pedidos, na mesma ordem. Para restart, pull,
+// build e down o grafo só ordena: as dependências de fora do alvo não são executadas. Um spec
+// passa a depender dos alvos que alcançava pelas dependências removidas.
+func (p *startupPlan) targetsOnly() *startupPlan {
+	order := make([]string, 0, len(p.Targets))
+	for _, spec := range p.Order {
+		if containsString(p.Targets, spec) {
+			order = append(order, spec)
+		}
+	}
+
+	deps := make(map[string][]string, len(order))
+	for _, spec := range order {
+		resolved := []string{}
+		visited := make(map[string]bool)
+		var walk func(spec string)
+		walk = func(spec string) {
+			for _, dep := range p.Deps[spec] {
+				if visited[dep] {
+					continue
+				}
+				visited[dep] = true
+				if containsString(p.Targets, dep) {
+					resolved = append(resolved, dep)
+				} else {
+					walk(dep)
+				}
+			}
+		}
+		walk(spec)
+		deps[spec] = resolved
+	}
+
+	return &startupPlan{Order: order, Waves: planWaves(order, deps), Deps: deps, Targets: p.Targets}
+}
+
+// topologicalOrder percorre o grafo em profundidade a partir das raízes, na ordem em que
+// aparecem, emitindo cada dependência antes de quem depende dela.
+func topologicalOrder(roots []string, deps map[string][]string) ([]string, error) {
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[string]int)
+	var order []string
+	var path []string
+
+	var visit func(spec string) error
+	visit = func(spec string) error {
+		switch state[spec] {
+		case done:
+			return nil
+		case visiting:
+			start := 0
+			for i, p := range path {
+				if p == spec {
+					start = i
+				}
+			}
+			cycle := append(append([]string{}, path[start:]...), spec)
+			return workspace.Invalid(i18n.Errorf("graph.cycle", strings.Join(cycle, " -> ")))
+		}
+
+		state[spec] = visiting
+		path = append(path, spec)
+		for _, dep := range deps[spec] {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[spec] = done
+		order = append(order, spec)
+		return nil
+	}
+
+	for _, root := range roots {
+		if err := visit(root); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package commands
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Disneyjr/dcm/internal/workspace"
+)
+
+func TestPlanStartup(t *testing.T) {
+	ws := &workspace.Workspace{
+		Projects: map[string]workspace.Project{
+			"database": {Path: "./database"},
+			"cache":    {Path: "./cache"},
+			"api":      {Path: "./api", DependsOn: []string{"database", "cache"}},
+			"frontend": {Path: "./frontend", DependsOn: []string{"api"}},
+			"docs":     {Path: "./docs"},
+		},
+	}
+
+	plan, err := planStartup(ws, []string{"frontend", "docs"})
+	if err != nil {
+		t.Fatalf("planStartup failed: %v", err)
+	}
+
+	expectedOrder := []string{"database", "cache", "api", "frontend", "docs"}
+	if !reflect.DeepEqual(plan.Order, expectedOrder) {
+		t.Errorf("unexpected order: %v", plan.Order)
+	}
+
+	expectedWaves := [][]string{{"database", "cache", "docs"}, {"api"}, {"frontend"}}
+	if !reflect.DeepEqual(plan.Waves, expectedWaves) {
+		t.Errorf("unexpected waves: %v", plan.Waves)
+	}
+}
+
+func TestPlanStartupServiceSpecs(t *testing.T) {
+	ws := &workspace.Workspace{
+		Projects: map[string]workspace.Project{
+			"infra": {Path: "./infra"},
+			"app": {
+				Path: "./app",
+				Services: map[string]workspace.ServiceOptions{
+					"worker": {DependsOn: []string{"infra:queue"}},
+					"web":    {DependsOn: []string{"app:worker"}},
+				},
+			},
+		},
+	}
+
+	plan, err := planStartup(ws, []string{"app:web"})
+	if err != nil {
+		t.Fatalf("planStartup failed: %v", err)
+	}
+	if !reflect.DeepEqual(plan.Order, []string{"infra:queue", "app:worker", "app:web"}) {
+		t.Errorf("unexpected order: %v", plan.Order)
+	}
+
+	// Quando o projeto inteiro está no grupo, o spec "infra:queue" é satisfeito por "infra"
+	plan, err = planStartup(ws, []string{"app:worker", "infra"})
+	if err != nil {
+		t.Fatalf("planStartup failed: %v", err)
+	}
+	if !reflect.DeepEqual(plan.Order, []string{"infra", "app:worker"}) {
+		t.Errorf("unexpected order: %v", plan.Order)
+	}
+}
+
+func TestPlanStartupFoldsIntoDependencyProject(t *testing.T) {
+	ws := &workspace.Workspace{
+		Projects: map[string]workspace.Project{
+			"db":  {Path: "./db"},
+			"api": {Path: "./api", DependsOn: []string{"db"}},
+			"web": {Path: "./web", DependsOn: []string{"api"}},
+		},
+	}
+
+	// "api" só entra inteiro pelo dependsOn de "web", mas ainda assim absorve "api:app"
+	plan, err := planStartup(ws, []string{"web", "api:app"})
+	if err != nil {
+		t.Fatalf("planStartup failed: %v", err)
+	}
+	if !reflect.DeepEqual(plan.Order, []string{"db", "api", "web"}) {
+		t.Errorf("unexpected order: %v", plan.Order)
+	}
+	if !reflect.DeepEqual(plan.Waves, [][]string{{"db"}, {"api"}, {"web"}}) {
+		t.Errorf("unexpected waves: %v", plan.Waves)
+	}
+	if !reflect.DeepEqual(plan.Targets, []string{"web", "api"}) {
+		t.Errorf("unexpected targets: %v", plan.Targets)
+	}
+}
+
+func TestPlanStartupCycle(t *testing.T) {
+	ws := &workspace.Workspace{
+		Projects: map[string]workspace.Project{
+			"a": {Path: "./a", DependsOn: []string{"b"}},
+			"b": {Path: "./b", DependsOn: []string{"c"}},
+			"c": {Path: "./c", DependsOn: []string{"a"}},
+		},
+	}
+
+	_, err := planStartup(ws, []string{"a"})
+	if err == nil {
+		t.Fatal("expected error for dependency cycle, got nil")
+	}
+	if !strings.Contains(err.Error(), "a -> b -> c -> a") {
+		t.Errorf("expected readable cycle path, got %v", err)
+	}
+}
@@ -0,0 +1,157 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Disneyjr/dcm/internal/i18n"
+	"github.com/Disneyjr/dcm/internal/state"
+	"github.com/Disneyjr/dcm/utils"
+)
+
+// HistoryResult é a saída estruturada do "dcm history".
+type HistoryResult struct {
+	Runs []state.Run `json:"runs" yaml:"runs"` // Da mais recente para a mais antiga
+}
+
+// resultLabels guarda a chave do catálogo de cada resultado.
+var resultLabels = map[string]string{
+	state.ResultOK:          "history.result_ok",
+	state.ResultPartial:     "history.result_partial",
+	state.ResultFailed:      "history.result_failed",
+	state.ResultInterrupted: "history.result_interrupted",
+}
+
+func downArgs(removeVolumes bool) []string {
+	if removeVolumes {
+		return []string{"-v"}
+	}
+	return nil
+}
+
+func runResult(err error) string {
+	switch {
+	case err == nil:
+		return state.ResultOK
+	case errors.Is(err, context.Canceled):
+		return state.ResultInterrupted
+	case errors.Is(err, ErrPartialFailure):
+		return state.ResultPartial
+	}
+	return state.ResultFailed
+}
+
+// recordRun registra a execução no estado do workspace. No dry-run nada é gravado, e uma falha
+// ao gravar vira um aviso: o histórico nunca muda o resultado do comando.
+func (r *Runner) recordRun(run state.Run, err error) {
+	if r.DryRun || r.Workspace.BaseDir == "" {
+		return
+	}
+
+	run.Finished = time.Now()
+	run.Result = runResult(err)
+	if err != nil {
+		run.Error = err.Error()
+	}
+
+	updateErr := state.Update(r.Workspace.BaseDir, func(s *state.State) error {
+		s.Add(run)
+		return nil
+	})
+	if updateErr != nil {
+		r.printf("%s%s\n", utils.Icon("yellow", "⚠️"), i18n.T("history.write_error", updateErr))
+	}
+}
+
+// History imprime as últimas execuções registradas no workspace (limit <= 0 mostra todas).
+func (r *Runner) History(limit int) error {
+	s, err := state.Load(r.Workspace.BaseDir)
+	if err != nil {
+		return err
+	}
+
+	runs := make([]state.Run, 0, len(s.Runs))
+	for i := len(s.Runs) - 1; i >= 0; i-- {
+		if limit > 0 && len(runs) == limit {
+			break
+		}
+		runs = append(runs, s.Runs[i])
+	}
+
+	if r.structuredOutput() {
+		return r.printStructured(HistoryResult{Runs: runs})
+	}
+
+	if len(runs) == 0 {
+		r.printf("%s\n", i18n.T("history.empty"))
+		return nil
+	}
+
+	w := tabwriter.NewWriter(r.Out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, i18n.T("history.header"))
+	for _, run := range runs {
+		command := strings.TrimSpace(run.Command + " " + strings.Join(run.Args, " "))
+		target := run.Target
+		if target == "" {
+			target = i18n.T("history.all_targets")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			run.Started.Local().Format("2006-01-02 15:04:05"),
+			command,
+			target,
+			i18n.T(resultLabels[run.Result]),
+			run.Finished.Sub(run.Started).Round(100*time.Millisecond),
+			strings.Join(run.Specs, ", "))
+	}
+	return w.Flush()
+}
+
+// DownLast para exatamente o que o último "up" registrado deixou rodando, na ordem inversa em que
+// foi iniciado. Specs que um "down" posterior já parou ficam de fora, e a execução registrada aponta
+// o "up" desfeito: um segundo "down --last" passa ao "up" anterior.
+func (r *Runner) DownLast(ctx context.Context, removeVolumes bool) (err error) {
+	s, err := state.Load(r.Workspace.BaseDir)
+	if err != nil {
+		return err
+	}
+	last, specs := s.LastRun("up")
+	if last == nil {
+		for _, run := range s.Runs {
+			if run.Command == "up" && len(run.Specs) > 0 {
+				return i18n.Errorf("history.nothing_to_undo")
+			}
+		}
+		return i18n.Errorf("history.no_up")
+	}
+
+	target := last.Target
+	if target == "" {
+		target = i18n.T("history.all_targets")
+	}
+	r.progressf("%s%s\n\n", utils.Icon("cyan", "⏹️"), i18n.T("history.stopping_last", target, last.Started.Local().Format("2006-01-02 15:04:05")))
+
+	run := r.startRun("down", last.Target, append(downArgs(removeVolumes), "--last"), false)
+	run.Undoes = last.Started
+	defer func() { r.finishRun(run, err) }()
+
+	failed := 0
+	for i := len(specs) - 1; i >= 0 && ctx.Err() == nil; i-- {
+		spec := specs[i]
+		if err := r.downStep(ctx, run, spec, removeVolumes); err != nil {
+			failed++
+			continue
+		}
+		run.Specs = append(run.Specs, spec)
+	}
+	if err := interruption(ctx); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return groupFailure(len(specs)-failed, i18n.Errorf("history.stop_failed", failed))
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Disneyjr/dcm/internal/errclass"
+	"github.com/Disneyjr/dcm/internal/state"
+	"github.com/Disneyjr/dcm/internal/workspace"
+)
+
+func TestDownLast(t *testing.T) {
+	ws := &workspace.Workspace{
+		BaseDir: t.TempDir(),
+		Projects: map[string]workspace.Project{
+			"db":    {Path: "./db"},
+			"api":   {Path: "./api"},
+			"other": {Path: "./other"},
+		},
+	}
+
+	r, out := dryRunner(ws)
+	if err := r.DownLast(context.Background(), false); err == nil {
+		t.Error("expected error without a recorded up")
+	}
+
+	state.Update(ws.BaseDir, func(s *state.State) error {
+		s.Add(state.Run{Command: "up", Target: "dev", Specs: []string{"db", "api"}, Result: state.ResultOK})
+		s.Add(state.Run{Command: "restart", Target: "other", Specs: []string{"other"}, Result: state.ResultOK})
+		return nil
+	})
+
+	if err := r.DownLast(context.Background(), false); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	api := strings.Index(out.String(), "cd ./api && docker compose down")
+	db := strings.Index(out.String(), "cd ./db && docker compose down")
+	if api < 0 || db < 0 || api > db {
+		t.Errorf("expected api to stop before db:\n%s", out)
+	}
+	if strings.Contains(out.String(), "./other") {
+		t.Errorf("expected only what the last up started to stop:\n%s", out)
+	}
+}
+
+func TestDownLastTwice(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("usa sh")
+	}
+
+	dir := t.TempDir()
+	ws := &workspace.Workspace{
+		BaseDir: dir,
+		Projects: map[string]workspace.Project{
+			"db":  {Path: dir},
+			"api": {Path: dir},
+		},
+	}
+	state.Update(dir, func(s *state.State) error {
+		s.Add(state.Run{Command: "up", Target: "dev", Specs: []string{"db", "api"}, Started: time.Now(), Result: state.ResultOK})
+		return nil
+	})
+
+	// Fora do dry-run, para que o "down --last" seja registrado; o "engine" só repete os argumentos
+	r, out := testRunner(ws)
+	r.Engine = Engine{Command: "sh", Args: []string{"-c", `echo "$@"`, "sh"}}
+	if err := r.DownLast(context.Background(), false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	s, _ := state.Load(dir)
+	if down := s.Runs[len(s.Runs)-1]; down.Command != "down" || !down.Undoes.Equal(s.Runs[0].Started) {
+		t.Errorf("expected a down run tied to the up, got %+v", down)
+	}
+
+	before := len(out.String())
+	err := r.DownLast(context.Background(), false)
+	if err == nil || !strings.Contains(err.Error(), "já foi parado") {
+		t.Errorf("expected nothing left to undo, got %v", err)
+	}
+	if strings.Contains(out.String()[before:], "down") {
+		t.Errorf("expected nothing to stop the second time:\n%s", out)
+	}
+}
+
+func TestRecordRun(t *testing.T) {
+	ws := &workspace.Workspace{BaseDir: t.TempDir()}
+
+	r, _ := testRunner(ws)
+	r.recordRun(state.Run{Command: "up", Target: "dev", Specs: []string{"api"}}, errclass.Wrap(ErrPartialFailure, context.DeadlineExceeded))
+
+	s, err := state.Load(ws.BaseDir)
+	if err != nil || len(s.Runs) != 1 {
+		t.Fatalf("expected one run, got %+v (%v)", s, err)
+	}
+	if run := s.Runs[0]; run.Result != state.ResultPartial || run.Error == "" || run.Finished.IsZero() {
+		t.Errorf("unexpected run: %+v", run)
+	}
+}
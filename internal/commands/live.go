@@ -0,0 +1,230 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Disneyjr/dcm/internal/i18n"
+	"github.com/Disneyjr/dcm/utils"
+	"github.com/Disneyjr/dcm/utils/terminal"
+)
+
+// liveInterval é o intervalo entre dois quadros do spinner.
+const liveInterval = 100 * time.Millisecond
+
+var (
+	spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+	plainFrames   = []string{"-", "\\", "|", "/"}
+)
+
+// LiveReporter mostra o progresso em um terminal: as mensagens do ConsoleReporter vão ficando
+// acima e, no fim da tela, cada spec em andamento tem uma linha com spinner e tempo decorrido, que
+// é redesenhada no lugar. Só funciona em terminais que aceitam sequências ANSI (terminal.Interactive).
+//
+// LiveReporter também é um io.Writer, e a saída do Runner deve passar por ele: o que é escrito
+// aparece acima das linhas ao vivo, em vez de por cima delas. Para o stderr, que costuma ir para o
+// mesmo terminal, use Wrap.
+type LiveReporter struct {
+	mu      sync.Mutex
+	out     io.Writer
+	console *ConsoleReporter // Escreve direto em out, sempre com as linhas ao vivo apagadas
+
+	rows    []*liveRow    // Specs em andamento, na ordem em que começaram
+	drawn   int           // Quantas linhas ao vivo estão na tela agora
+	streams []*liveStream // O próprio out e os writers de Wrap
+	frame   int
+	stop    chan struct{} // Encerra a animação; nil fora de uma operação
+}
+
+// liveStream é um destino das escritas que passam pelo LiveReporter.
+type liveStream struct {
+	l       *LiveReporter
+	out     io.Writer
+	partial []byte // Saída sem quebra de linha, guardada até a linha terminar
+}
+
+func (s *liveStream) Write(p []byte) (int, error) {
+	s.l.mu.Lock()
+	defer s.l.mu.Unlock()
+	return s.l.write(s, p)
+}
+
+type liveRow struct {
+	spec    string
+	detail  string
+	started time.Time
+}
+
+func NewLiveReporter(out io.Writer) *LiveReporter {
+	l := &LiveReporter{out: out, console: NewConsoleReporter(out, false)}
+	l.streams = []*liveStream{{l: l, out: out}}
+	return l
+}
+
+// Wrap devolve um writer para w que, como Write, apaga as linhas ao vivo antes de cada escrita e as
+// redesenha depois. Serve para o stderr, que aparece no mesmo terminal mas pode ser redirecionado.
+func (l *LiveReporter) Wrap(w io.Writer) io.Writer {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	s := &liveStream{l: l, out: w}
+	l.streams = append(l.streams, s)
+	return s
+}
+
+func (l *LiveReporter) Report(e Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.clear()
+	defer l.draw()
+
+	switch e.Kind {
+	case OperationStarted:
+		l.rows = nil
+		if l.stop == nil {
+			l.stop = make(chan struct{})
+			go l.animate(l.stop)
+		}
+		l.console.Report(e)
+	case StepStarted:
+		l.rows = append(l.rows, &liveRow{spec: e.Spec, started: e.Time})
+	case ReadinessWaiting:
+		if row := l.row(e.Spec); row != nil {
+			row.detail = i18n.T("live.waiting", e.Detail)
+		}
+	case ReadinessReady:
+		if row := l.row(e.Spec); row != nil {
+			row.detail = ""
+		}
+	case StepFinished:
+		l.remove(e.Spec)
+		fmt.Fprintf(l.out, "%s%s (%s)\n", utils.Icon("green", "✅"), e.Spec, e.Duration.Round(100*time.Millisecond))
+	case StepFailed:
+		l.remove(e.Spec)
+		l.console.Report(e)
+	case OperationFinished:
+		l.rows = nil
+		if l.stop != nil {
+			close(l.stop)
+			l.stop = nil
+		}
+		for _, s := range l.streams {
+			if len(s.partial) > 0 {
+				s.out.Write(append(s.partial, '\n'))
+				s.partial = nil
+			}
+		}
+		l.console.Report(e)
+	default:
+		l.console.Report(e)
+	}
+}
+
+// Write escreve p acima das linhas ao vivo. Linhas incompletas esperam pelo resto enquanto há linhas
+// ao vivo na tela, já que o cursor precisa estar no começo de uma linha para redesenhá-las.
+func (l *LiveReporter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.write(l.streams[0], p)
+}
+
+func (l *LiveReporter) write(s *liveStream, p []byte) (int, error) {
+	data := append(s.partial, p...)
+	s.partial = nil
+	if l.drawn == 0 {
+		_, err := s.out.Write(data)
+		return len(p), err
+	}
+
+	end := bytes.LastIndexByte(data, '\n') + 1
+	s.partial = append([]byte{}, data[end:]...)
+	if end == 0 {
+		return len(p), nil
+	}
+	l.clear()
+	_, err := s.out.Write(data[:end])
+	l.draw()
+	return len(p), err
+}
+
+func (l *LiveReporter) animate(stop chan struct{}) {
+	ticker := time.NewTicker(liveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			l.mu.Lock()
+			l.frame++
+			l.clear()
+			l.draw()
+			l.mu.Unlock()
+		}
+	}
+}
+
+func (l *LiveReporter) row(spec string) *liveRow {
+	for _, row := range l.rows {
+		if row.spec == spec {
+			return row
+		}
+	}
+	return nil
+}
+
+func (l *LiveReporter) remove(spec string) {
+	for i, row := range l.rows {
+		if row.spec == spec {
+			l.rows = append(l.rows[:i], l.rows[i+1:]...)
+			return
+		}
+	}
+}
+
+// clear apaga as linhas ao vivo, deixando o cursor onde elas começavam.
+func (l *LiveReporter) clear() {
+	if l.drawn > 0 {
+		fmt.Fprintf(l.out, "\033[%dA\r\033[J", l.drawn)
+		l.drawn = 0
+	}
+}
+
+// draw desenha as linhas ao vivo a partir do cursor. Cada uma é cortada na largura do terminal: uma
+// linha quebrada ocuparia duas e o clear apagaria a menos.
+func (l *LiveReporter) draw() {
+	if len(l.rows) == 0 {
+		return
+	}
+
+	frames := spinnerFrames
+	if terminal.Plain() {
+		frames = plainFrames
+	}
+	spinner := utils.Colorize("cyan", frames[l.frame%len(frames)])
+
+	nameWidth := 0
+	for _, row := range l.rows {
+		nameWidth = max(nameWidth, len([]rune(row.spec)))
+	}
+	width := terminal.Width(l.out)
+
+	var b strings.Builder
+	for _, row := range l.rows {
+		text := fmt.Sprintf("%-*s  %s", nameWidth, row.spec, time.Since(row.started).Round(100*time.Millisecond))
+		if row.detail != "" {
+			text += "  " + row.detail
+		}
+		// O spinner e o espaço ocupam duas colunas; a última fica livre para o terminal não quebrar a linha
+		if runes := []rune(text); width > 3 && len(runes) > width-3 {
+			text = string(runes[:width-3])
+		}
+		fmt.Fprintf(&b, "%s %s\n", spinner, text)
+	}
+	io.WriteString(l.out, b.String())
+	l.drawn = len(l.rows)
+}
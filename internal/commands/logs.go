@@ -0,0 +1,168 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/Disneyjr/dcm/internal/i18n"
+	"github.com/Disneyjr/dcm/internal/workspace"
+	"github.com/Disneyjr/dcm/utils"
+)
+
+type LogsOptions struct {
+	Follow bool
+	Since  string // Repassado ao compose (ex: "10m", "2024-01-01T00:00:00")
+	Tail   string // Número de linhas por container ou "all"
+	Grep   string // Expressão regular; apenas linhas que casam são exibidas
+}
+
+var logColors = []string{"cyan", "yellow", "green", "magenta", "blue", "red"}
+
+// logColor escolhe uma cor estável para o prefixo de cada projeto.
+func logColor(name string) string {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	return logColors[h.Sum32()%uint32(len(logColors))]
+}
+
+func (o LogsOptions) composeArgs(targetService string) []string {
+	args := []string{"logs", "--no-color"}
+	if o.Follow {
+		args = append(args, "--follow")
+	}
+	if o.Since != "" {
+		args = append(args, "--since", o.Since)
+	}
+	if o.Tail != "" {
+		args = append(args, "--tail", o.Tail)
+	}
+	if targetService != "" {
+		args = append(args, targetService)
+	}
+	return args
+}
+
+// logWriter serializa as linhas vindas de vários projetos para que não se misturem.
+type logWriter struct {
+	mu     sync.Mutex
+	out    io.Writer
+	filter *regexp.Regexp
+	width  int
+}
+
+func (w *logWriter) stream(ctx context.Context, name string, r io.Reader) {
+	prefix := utils.Colorize(logColor(name), fmt.Sprintf("%-*s |", w.width, name))
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return
+		}
+		line := scanner.Text()
+		if w.filter != nil && !w.filter.MatchString(line) {
+			continue
+		}
+		w.mu.Lock()
+		fmt.Fprintf(w.out, "%s %s\n", prefix, line)
+		w.mu.Unlock()
+	}
+}
+
+func resolveLogTargets(ws *workspace.Workspace, target string) ([]string, error) {
+	services, _, err := resolveTargets(ws, target)
+	if err != nil {
+		return nil, err
+	}
+
+	var targets []string
+	for _, spec := range services {
+		if !containsString(targets, spec) {
+			targets = append(targets, spec)
+		}
+	}
+	return targets, nil
+}
+
+// LogsGroup acompanha ao mesmo tempo os logs do grupo, projeto ou spec (ou do workspace inteiro,
+// se groupName for vazio), com um prefixo colorido por projeto. Cancelar ctx (Ctrl-C) encerra todos
+// os streams.
+func (r *Runner) LogsGroup(ctx context.Context, groupName string, opts LogsOptions) error {
+	return r.StreamLogs(ctx, groupName, opts, r.Out)
+}
+
+// StreamLogs escreve em out os logs do alvo até o fim dos streams ou o cancelamento de ctx.
+func (r *Runner) StreamLogs(ctx context.Context, target string, opts LogsOptions, out io.Writer) error {
+	ws := r.Workspace
+	targets, err := resolveLogTargets(ws, target)
+	if err != nil {
+		return err
+	}
+
+	writer := &logWriter{out: out}
+	if opts.Grep != "" {
+		if writer.filter, err = regexp.Compile(opts.Grep); err != nil {
+			return i18n.Errorf("logs.invalid_filter", opts.Grep, err)
+		}
+	}
+	for _, spec := range targets {
+		if len(spec) > writer.width {
+			writer.width = len(spec)
+		}
+	}
+
+	if r.DryRun {
+		for _, spec := range targets {
+			projectName, targetService := splitServiceSpec(spec)
+			if project, exists := ws.Projects[projectName]; exists {
+				r.runCompose(ctx, project, spec, opts.composeArgs(targetService), false)
+			}
+		}
+		return nil
+	}
+
+	// Os streams com --follow não terminam sozinhos, então os logs nunca são limitados
+	errs := runParallel(targets, 0, func(spec string) error {
+		projectName, targetService := splitServiceSpec(spec)
+		project, exists := ws.Projects[projectName]
+		if !exists {
+			return i18n.Errorf("workspace.project_not_found", projectName)
+		}
+
+		c := r.newCommand(ctx, r.Engine.Command, r.Engine.composeArgs(project, opts.composeArgs(targetService))...)
+		c.Dir = project.Path
+		stdout, err := c.StdoutPipe()
+		if err != nil {
+			return err
+		}
+		c.Stderr = c.Stdout
+
+		if err := c.Start(); err != nil {
+			return i18n.Errorf("logs.stream_error", err)
+		}
+		writer.stream(ctx, spec, stdout)
+
+		if err := c.Wait(); err != nil && ctx.Err() == nil {
+			return i18n.Errorf("logs.stream_error", err)
+		}
+		return nil
+	})
+
+	var failed []string
+	for i, err := range errs {
+		if err != nil {
+			fmt.Fprintf(out, "%s%s: %v\n", utils.Icon("red", "❌"), targets[i], err)
+			failed = append(failed, targets[i])
+		}
+	}
+	if len(failed) > 0 {
+		return groupFailure(len(targets)-len(failed), i18n.Errorf("logs.failed", strings.Join(failed, ", ")))
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestLogWriterStream(t *testing.T) {
+	var out strings.Builder
+	w := &logWriter{out: &out, filter: regexp.MustCompile("ERROR"), width: 6}
+
+	w.stream(context.Background(), "api", strings.NewReader("web-1 | ok\nweb-1 | ERROR boom\n"))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected only the filtered line, got %q", out.String())
+	}
+	if !strings.Contains(lines[0], "api    |") || !strings.HasSuffix(lines[0], "web-1 | ERROR boom") {
+		t.Errorf("unexpected prefixed line: %q", lines[0])
+	}
+}
+
+func TestLogsComposeArgs(t *testing.T) {
+	opts := LogsOptions{Follow: true, Since: "10m", Tail: "50"}
+	expected := []string{"logs", "--no-color", "--follow", "--since", "10m", "--tail", "50", "web"}
+	if args := opts.composeArgs("web"); !reflect.DeepEqual(args, expected) {
+		t.Errorf("unexpected args: %v", args)
+	}
+
+	if logColor("api") != logColor("api") {
+		t.Error("expected stable color per project")
+	}
+}
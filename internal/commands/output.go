@@ -0,0 +1,275 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sort"
+
+	"github.com/Disneyjr/dcm/internal/i18n"
+	"github.com/Disneyjr/dcm/internal/workspace"
+	"github.com/Disneyjr/dcm/utils"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+	OutputYAML = "yaml"
+)
+
+func ParseOutputFormat(format string) (string, error) {
+	switch format {
+	case OutputText, OutputJSON, OutputYAML:
+		return format, nil
+	case "yml":
+		return OutputYAML, nil
+	}
+	return "", i18n.Errorf("output.invalid_format", format)
+}
+
+// structuredOutput indica se status, list, inspect e history imprimem um documento em vez de texto.
+func (r *Runner) structuredOutput() bool {
+	return r.OutputFormat == OutputJSON || r.OutputFormat == OutputYAML
+}
+
+func writeStructured(w io.Writer, format string, v any) error {
+	if format == OutputYAML {
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+		return enc.Close()
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+type ProjectInfo struct {
+	Name        string   `json:"name" yaml:"name"`
+	Path        string   `json:"path" yaml:"path"`
+	Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+	DependsOn   []string `json:"dependsOn,omitempty" yaml:"dependsOn,omitempty"`
+	Groups      []string `json:"groups" yaml:"groups"` // Grupos que incluem o projeto, considerando extends
+}
+
+type GroupInfo struct {
+	Name     string   `json:"name" yaml:"name"`
+	Extends  string   `json:"extends,omitempty" yaml:"extends,omitempty"`
+	Parallel bool     `json:"parallel" yaml:"parallel"`
+	Services []string `json:"services" yaml:"services"` // Serviços resolvidos, incluindo os herdados
+	Error    string   `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+type ListResult struct {
+	Projects []ProjectInfo `json:"projects" yaml:"projects"`
+	Groups   []GroupInfo   `json:"groups" yaml:"groups"`
+}
+
+type InspectService struct {
+	Spec      string   `json:"spec" yaml:"spec"`
+	Project   string   `json:"project" yaml:"project"`
+	Service   string   `json:"service,omitempty" yaml:"service,omitempty"`
+	Path      string   `json:"path" yaml:"path"`
+	DependsOn []string `json:"dependsOn,omitempty" yaml:"dependsOn,omitempty"`
+}
+
+type InspectResult struct {
+	Group       string           `json:"group" yaml:"group"`
+	Parallel    bool             `json:"parallel" yaml:"parallel"`
+	MaxParallel int              `json:"maxParallel,omitempty" yaml:"maxParallel,omitempty"` // Limite efetivo (grupo ou --jobs)
+	OnFailure   string           `json:"onFailure" yaml:"onFailure"`
+	Services    []InspectService `json:"services" yaml:"services"` // Na ordem de inicialização
+	Waves       [][]string       `json:"waves" yaml:"waves"`
+}
+
+type ContainerInfo struct {
+	Name    string `json:"name" yaml:"name"`
+	Service string `json:"service" yaml:"service"`
+	State   string `json:"state" yaml:"state"`
+	Health  string `json:"health,omitempty" yaml:"health,omitempty"`
+	Status  string `json:"status,omitempty" yaml:"status,omitempty"`
+}
+
+type ProjectStatus struct {
+	Name       string          `json:"name" yaml:"name"`
+	Path       string          `json:"path" yaml:"path"`
+	Containers []ContainerInfo `json:"containers" yaml:"containers"`
+	Error      string          `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+type StatusResult struct {
+	Projects []ProjectStatus `json:"projects" yaml:"projects"`
+}
+
+func sortedProjectNames(ws *workspace.Workspace) []string {
+	names := make([]string, 0, len(ws.Projects))
+	for name := range ws.Projects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedGroupNames(ws *workspace.Workspace) []string {
+	names := make([]string, 0, len(ws.Groups))
+	for name := range ws.Groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func buildListResult(ws *workspace.Workspace) ListResult {
+	result := ListResult{Projects: []ProjectInfo{}, Groups: []GroupInfo{}}
+	membership := make(map[string][]string)
+
+	for _, name := range sortedGroupNames(ws) {
+		group := ws.Groups[name]
+		info := GroupInfo{Name: name, Extends: group.Extends, Services: []string{}}
+
+		services, parallel, err := resolveGroupServices(ws, name, make(map[string]bool))
+		if err != nil {
+			info.Error = err.Error()
+		} else {
+			info.Services = services
+			info.Parallel = parallel
+			for _, spec := range services {
+				projectName, _ := splitServiceSpec(spec)
+				if !containsString(membership[projectName], name) {
+					membership[projectName] = append(membership[projectName], name)
+				}
+			}
+		}
+		result.Groups = append(result.Groups, info)
+	}
+
+	for _, name := range sortedProjectNames(ws) {
+		proj := ws.Projects[name]
+		groups := membership[name]
+		if groups == nil {
+			groups = []string{}
+		}
+		result.Projects = append(result.Projects, ProjectInfo{
+			Name:        name,
+			Path:        proj.Path,
+			Description: proj.Description,
+			DependsOn:   proj.DependsOn,
+			Groups:      groups,
+		})
+	}
+	return result
+}
+
+func (r *Runner) buildInspectResult(groupName string) (*InspectResult, error) {
+	ws := r.Workspace
+	services, parallel, err := resolveGroupServices(ws, groupName, make(map[string]bool))
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := planStartup(ws, services)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &InspectResult{Group: groupName, Parallel: parallel, MaxParallel: concurrencyLimit(ws, groupName, r.Jobs), OnFailure: failurePolicy(ws, groupName, r.FailFast), Services: []InspectService{}, Waves: plan.Waves}
+	for _, spec := range plan.Order {
+		projectName, targetService := splitServiceSpec(spec)
+		result.Services = append(result.Services, InspectService{
+			Spec:      spec,
+			Project:   projectName,
+			Service:   targetService,
+			Path:      ws.Projects[projectName].Path,
+			DependsOn: plan.Deps[spec],
+		})
+	}
+	return result, nil
+}
+
+func (r *Runner) buildStatusResult(ctx context.Context, specs []string) StatusResult {
+	result := StatusResult{Projects: []ProjectStatus{}}
+
+	for _, spec := range specs {
+		projectName, targetService := splitServiceSpec(spec)
+		proj := r.Workspace.Projects[projectName]
+		status := ProjectStatus{Name: spec, Path: proj.Path, Containers: []ContainerInfo{}}
+
+		containers, err := r.composeContainers(ctx, proj, targetService)
+		if err != nil {
+			status.Error = err.Error()
+		}
+		for _, c := range containers {
+			status.Containers = append(status.Containers, ContainerInfo(c))
+		}
+		result.Projects = append(result.Projects, status)
+	}
+	return result
+}
+
+// CollectStatus consulta o estado dos containers do alvo (vazio = workspace inteiro) sem imprimir nada.
+func (r *Runner) CollectStatus(ctx context.Context, target string) (StatusResult, error) {
+	services, _, err := resolveTargets(r.Workspace, target)
+	if err != nil {
+		return StatusResult{}, err
+	}
+	return r.buildStatusResult(ctx, services), nil
+}
+
+// PrintConfig imprime o workspace como o dcm o enxerga: variáveis interpoladas e caminhos absolutos.
+// Sem --output, o formato é JSON.
+func (r *Runner) PrintConfig() error {
+	format := r.OutputFormat
+	if !r.structuredOutput() {
+		format = OutputJSON
+	}
+	return writeStructured(r.dataOut(), format, r.Workspace)
+}
+
+// PrintConfigSources mostra de qual arquivo veio cada valor do workspace mesclado.
+func (r *Runner) PrintConfigSources() error {
+	ws := r.Workspace
+	keys := make([]string, 0, len(ws.Sources))
+	for key := range ws.Sources {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	if r.structuredOutput() {
+		sources := make([]ConfigSource, 0, len(keys))
+		for _, key := range keys {
+			sources = append(sources, ConfigSource{Key: key, File: ws.Sources[key]})
+		}
+		return r.printStructured(sources)
+	}
+
+	width := 0
+	for _, key := range keys {
+		if len(key) > width {
+			width = len(key)
+		}
+	}
+	for _, key := range keys {
+		r.printf("%-*s  %s\n", width, key, utils.Colorize("cyan", ws.Sources[key]))
+	}
+	return nil
+}
+
+type ConfigSource struct {
+	Key  string `json:"key" yaml:"key"`
+	File string `json:"file" yaml:"file"`
+}
+
+func (r *Runner) printStructured(v any) error {
+	return writeStructured(r.dataOut(), r.OutputFormat, v)
+}
+
+func (r *Runner) dataOut() io.Writer {
+	if r.DataOut != nil {
+		return r.DataOut
+	}
+	return r.Out
+}
@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"context"
+	"os/exec"
+	"time"
+
+	"github.com/Disneyjr/dcm/internal/errclass"
+	"github.com/Disneyjr/dcm/internal/i18n"
+)
+
+// newCommand cria um comando ligado a ctx: quando ctx termina, o processo recebe o sinal de
+// interrupção e, se não encerrar em r.GracePeriod, é morto.
+func (r *Runner) newCommand(ctx context.Context, name string, args ...string) *exec.Cmd {
+	c := exec.CommandContext(ctx, name, args...)
+	c.WaitDelay = r.GracePeriod
+	setInterrupt(c)
+	return c
+}
+
+// interruption devolve o erro de uma operação cancelada, ou nil enquanto ctx segue ativo.
+func interruption(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return errclass.Wrap(err, i18n.Errorf("errors.interrupted"))
+	}
+	return nil
+}
+
+// sleepContext espera d ou até ctx terminar, o que vier primeiro.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,24 @@
+//go:build !windows
+
+package commands
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// setInterrupt coloca o comando em um grupo de processos próprio. Assim o Ctrl+C do terminal chega
+// só ao dcm, que repassa um único SIGINT para o grupo inteiro: o compose aborta sem limpar nada
+// quando recebe dois.
+func setInterrupt(c *exec.Cmd) {
+	c.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	c.Cancel = func() error {
+		err := syscall.Kill(-c.Process.Pid, syscall.SIGINT)
+		if errors.Is(err, syscall.ESRCH) {
+			return os.ErrProcessDone
+		}
+		return err
+	}
+}
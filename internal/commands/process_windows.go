@@ -0,0 +1,12 @@
+//go:build windows
+
+package commands
+
+import "os/exec"
+
+// setInterrupt não envia nada: no Windows não é possível mandar Ctrl+C para um processo específico,
+// e o Ctrl+C do console já chega a todos os processos anexados a ele. Em um timeout o processo só
+// é encerrado ao fim do GracePeriod do Runner.
+func setInterrupt(c *exec.Cmd) {
+	c.Cancel = func() error { return nil }
+}
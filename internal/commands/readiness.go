@@ -0,0 +1,200 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/Disneyjr/dcm/internal/i18n"
+	"github.com/Disneyjr/dcm/internal/state"
+	"github.com/Disneyjr/dcm/internal/workspace"
+	"github.com/Disneyjr/dcm/utils"
+)
+
+// containerState é o subconjunto da saída de "compose ps --format json" usado pelo dcm.
+type containerState struct {
+	Name    string `json:"Name"`
+	Service string `json:"Service"`
+	State   string `json:"State"`
+	Health  string `json:"Health"`
+	Status  string `json:"Status"`
+}
+
+// parseComposePS aceita tanto um array JSON (compose antigo) quanto um objeto por linha (compose >= 2.21).
+func parseComposePS(output []byte) ([]containerState, error) {
+	output = bytes.TrimSpace(output)
+	if len(output) == 0 {
+		return nil, nil
+	}
+
+	var containers []containerState
+	if output[0] == '[' {
+		if err := json.Unmarshal(output, &containers); err != nil {
+			return nil, i18n.Errorf("readiness.invalid_ps", err)
+		}
+		return containers, nil
+	}
+
+	for _, line := range bytes.Split(output, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		var c containerState
+		if err := json.Unmarshal(line, &c); err != nil {
+			return nil, i18n.Errorf("readiness.invalid_ps", err)
+		}
+		containers = append(containers, c)
+	}
+	return containers, nil
+}
+
+func (r *Runner) composeContainers(ctx context.Context, project workspace.Project, targetService string) ([]containerState, error) {
+	args := []string{"ps", "--format", "json"}
+	if targetService != "" {
+		args = append(args, targetService)
+	}
+
+	c := r.newCommand(ctx, r.Engine.Command, r.Engine.composeArgs(project, args)...)
+	c.Dir = project.Path
+	output, err := c.Output()
+	if err != nil {
+		return nil, i18n.Errorf("readiness.ps_error", err)
+	}
+	return parseComposePS(output)
+}
+
+func (r *Runner) checkHealthy(ctx context.Context, project workspace.Project, targetService string) error {
+	containers, err := r.composeContainers(ctx, project, targetService)
+	if err != nil {
+		return err
+	}
+	if len(containers) == 0 {
+		return i18n.Errorf("readiness.no_containers")
+	}
+
+	for _, c := range containers {
+		// Containers sem healthcheck só precisam estar rodando
+		if c.Health == "" {
+			if c.State != "running" {
+				return i18n.Errorf("readiness.container_state", c.Service, c.State)
+			}
+			continue
+		}
+		if c.Health != "healthy" {
+			return i18n.Errorf("readiness.container_state", c.Service, c.Health)
+		}
+	}
+	return nil
+}
+
+// readinessAttemptTimeout limita cada tentativa das verificações tcp e http. O interval é só a
+// pausa entre elas: um endpoint lento, mas saudável, precisa de mais tempo que isso para responder.
+const readinessAttemptTimeout = 10 * time.Second
+
+func checkTCP(ctx context.Context, address string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+func checkHTTP(ctx context.Context, url string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return i18n.Errorf("readiness.http_status", url, resp.StatusCode)
+	}
+	return nil
+}
+
+func (r *Runner) checkCommand(ctx context.Context, projectPath, command string) error {
+	var c *exec.Cmd
+	if runtime.GOOS == "windows" {
+		c = r.newCommand(ctx, "cmd", "/C", command)
+	} else {
+		c = r.newCommand(ctx, "sh", "-c", command)
+	}
+	c.Dir = projectPath
+
+	if output, err := c.CombinedOutput(); err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// checkReadiness faz uma tentativa da verificação; timeout vale para as do tipo tcp e http.
+func (r *Runner) checkReadiness(ctx context.Context, project workspace.Project, targetService string, timeout time.Duration) error {
+	readiness := project.Readiness
+	switch readiness.Type {
+	case workspace.ReadinessHealthy:
+		return r.checkHealthy(ctx, project, targetService)
+	case workspace.ReadinessTCP:
+		return checkTCP(ctx, readiness.Address, timeout)
+	case workspace.ReadinessHTTP:
+		return checkHTTP(ctx, readiness.URL, timeout)
+	case workspace.ReadinessCommand:
+		return r.checkCommand(ctx, project.Path, readiness.Command)
+	}
+	return readiness.Validate()
+}
+
+// waitReady repete a verificação de readiness do projeto até ela passar ou o timeout expirar.
+func (r *Runner) waitReady(ctx context.Context, run *state.Run, project workspace.Project, serviceSpec string) error {
+	if project.Readiness == nil {
+		return nil
+	}
+	if err := project.Readiness.Validate(); err != nil {
+		return err
+	}
+
+	timeout, interval, _ := project.Readiness.Durations()
+	if r.DryRun {
+		r.printf("%s[DRY-RUN] %s\n", utils.Icon("yellow", "🛠️"), i18n.T("readiness.dry_run", serviceSpec, project.Readiness.Type, timeout))
+		return nil
+	}
+
+	_, targetService := splitServiceSpec(serviceSpec)
+	waiting := r.event(run, ReadinessWaiting, serviceSpec)
+	waiting.Detail = project.Readiness.Type
+	r.emit(waiting)
+
+	start := time.Now()
+	deadline := start.Add(timeout)
+	for {
+		err := r.checkReadiness(ctx, project, targetService, min(time.Until(deadline), readinessAttemptTimeout))
+		if err == nil {
+			ready := r.event(run, ReadinessReady, serviceSpec)
+			ready.Duration = time.Since(start)
+			r.emit(ready)
+			return nil
+		}
+		if time.Now().Add(interval).After(deadline) {
+			return i18n.Errorf("readiness.timeout", serviceSpec, timeout, err)
+		}
+		if err := sleepContext(ctx, interval); err != nil {
+			return i18n.Errorf("readiness.interrupted", serviceSpec, err)
+		}
+	}
+}
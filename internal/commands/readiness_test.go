@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Disneyjr/dcm/internal/state"
+	"github.com/Disneyjr/dcm/internal/workspace"
+)
+
+func TestParseComposePS(t *testing.T) {
+	array := []byte(`[{"Service":"db","State":"running","Health":"healthy"},{"Service":"web","State":"running","Health":""}]`)
+	lines := []byte("{\"Service\":\"db\",\"State\":\"running\",\"Health\":\"starting\"}\n{\"Service\":\"web\",\"State\":\"exited\"}\n")
+
+	containers, err := parseComposePS(array)
+	if err != nil {
+		t.Fatalf("array parsing failed: %v", err)
+	}
+	if len(containers) != 2 || containers[0].Health != "healthy" {
+		t.Errorf("unexpected containers: %+v", containers)
+	}
+
+	containers, err = parseComposePS(lines)
+	if err != nil {
+		t.Fatalf("ndjson parsing failed: %v", err)
+	}
+	if len(containers) != 2 || containers[1].State != "exited" {
+		t.Errorf("unexpected containers: %+v", containers)
+	}
+}
+
+func TestWaitReadyTCPAndHTTP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	r, _ := testRunner(&workspace.Workspace{})
+	tcp := workspace.Project{Readiness: &workspace.Readiness{Type: "tcp", Address: listener.Addr().String(), Interval: "10ms"}}
+	if err := r.waitReady(context.Background(), &state.Run{Command: "up"}, tcp, "db"); err != nil {
+		t.Errorf("tcp readiness failed: %v", err)
+	}
+
+	healthy := workspace.Project{Readiness: &workspace.Readiness{Type: "http", URL: server.URL + "/health", Interval: "10ms"}}
+	if err := r.waitReady(context.Background(), &state.Run{Command: "up"}, healthy, "api"); err != nil {
+		t.Errorf("http readiness failed: %v", err)
+	}
+
+	unhealthy := workspace.Project{Readiness: &workspace.Readiness{Type: "http", URL: server.URL + "/down", Timeout: "50ms", Interval: "10ms"}}
+	start := time.Now()
+	if err := r.waitReady(context.Background(), &state.Run{Command: "up"}, unhealthy, "api"); err == nil {
+		t.Error("expected timeout error for non-2xx endpoint, got nil")
+	}
+	if time.Since(start) > time.Second {
+		t.Error("readiness did not respect timeout")
+	}
+}
+
+func TestWaitReadySlowEndpoint(t *testing.T) {
+	// Responde mais devagar que o interval: cada tentativa precisa esperar a resposta
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	r, _ := testRunner(&workspace.Workspace{})
+	slow := workspace.Project{Readiness: &workspace.Readiness{Type: "http", URL: server.URL, Timeout: "5s", Interval: "10ms"}}
+	start := time.Now()
+	if err := r.waitReady(context.Background(), &state.Run{Command: "up"}, slow, "api"); err != nil {
+		t.Errorf("slow http readiness failed: %v", err)
+	}
+	if time.Since(start) > 2*time.Second {
+		t.Errorf("expected the first attempt to succeed, took %s", time.Since(start))
+	}
+}
+
+func TestReadinessValidate(t *testing.T) {
+	invalid := []workspace.Readiness{
+		{Type: "unknown"},
+		{Type: "tcp"},
+		{Type: "http"},
+		{Type: "command"},
+		{Type: "healthy", Timeout: "abc"},
+	}
+	for _, r := range invalid {
+		if err := r.Validate(); err == nil {
+			t.Errorf("expected validation error for %+v", r)
+		}
+	}
+
+	if err := (workspace.Readiness{Type: "healthy", Timeout: "2m", Interval: "1s"}).Validate(); err != nil {
+		t.Errorf("unexpected validation error: %v", err)
+	}
+}
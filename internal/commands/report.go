@@ -0,0 +1,332 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Disneyjr/dcm/internal/i18n"
+	"github.com/Disneyjr/dcm/utils"
+)
+
+// Reporter mostra o andamento das operações a partir dos seus eventos. Report é chamado de várias
+// goroutines ao mesmo tempo nos grupos paralelos, então as implementações se sincronizam sozinhas.
+// Os reporters deste pacote acompanham uma operação por vez.
+type Reporter interface {
+	Report(e Event)
+}
+
+// ReporterFunc permite usar uma função comum como Reporter.
+type ReporterFunc func(Event)
+
+func (f ReporterFunc) Report(e Event) {
+	f(e)
+}
+
+type multiReporter []Reporter
+
+// MultiReporter repassa cada evento a todos os reporters, na ordem em que foram passados. Os nil
+// são ignorados.
+func MultiReporter(reporters ...Reporter) Reporter {
+	var m multiReporter
+	for _, r := range reporters {
+		if r != nil {
+			m = append(m, r)
+		}
+	}
+	return m
+}
+
+func (m multiReporter) Report(e Event) {
+	for _, r := range m {
+		r.Report(e)
+	}
+}
+
+// ConsoleReporter é a saída padrão do CLI: uma linha por acontecimento, com emojis e cores. Com
+// quiet só mostra falhas, serviços ignorados e o que ficou sem iniciar.
+type ConsoleReporter struct {
+	mu    sync.Mutex
+	out   io.Writer
+	quiet bool
+
+	// Os specs interrompidos antes de começar saem em uma única linha, antes do rollback ou no fim
+	notStarted []string
+}
+
+func NewConsoleReporter(out io.Writer, quiet bool) *ConsoleReporter {
+	return &ConsoleReporter{out: out, quiet: quiet}
+}
+
+func (c *ConsoleReporter) Report(e Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch e.Kind {
+	case OperationStarted:
+		c.notStarted = nil
+	case StepSkipped:
+		if errors.Is(e.Err, errNotStarted) {
+			c.notStarted = append(c.notStarted, e.Spec)
+			return
+		}
+	case RollbackStarted, OperationFinished:
+		if len(c.notStarted) > 0 {
+			io.WriteString(c.out, line("yellow", "⏹️", i18n.T("plan.stopped", strings.Join(c.notStarted, ", "))))
+			c.notStarted = nil
+		}
+	}
+
+	message, progress := consoleMessage(e)
+	if message == "" || (progress && c.quiet) {
+		return
+	}
+	io.WriteString(c.out, message)
+}
+
+// line formata uma linha de mensagem aberta por um emoji.
+func line(color, icon, text string) string {
+	return utils.Icon(color, icon) + text + "\n"
+}
+
+// consoleMessage devolve o texto do ConsoleReporter para e (vazio se o evento não tem mensagem) e
+// se ele é apenas progresso, omitido com quiet.
+func consoleMessage(e Event) (string, bool) {
+	switch e.Kind {
+	case OperationStarted:
+		return operationHeader(e), true
+	case OperationFinished:
+		if e.Err != nil {
+			// Quem chamou a operação mostra o erro
+			return "", true
+		}
+		return operationFooter(e), true
+	case WaveStarted:
+		if e.Waves > 1 {
+			return line("cyan", "📦", i18n.T("plan.wave", e.Wave, e.Waves, strings.Join(e.Specs, ", "))), true
+		}
+	case StepStarted:
+		return line("blue", "🚀", stepMessage(e)), true
+	case StepFinished:
+		if e.Operation == "up" {
+			projectName, _ := splitServiceSpec(e.Spec)
+			return line("green", "✅", i18n.T("up.ready", projectName)), true
+		}
+	case StepFailed:
+		// Os erros das ações já citam o spec; os do up e do down não
+		if _, ok := composeActions[e.Operation]; ok {
+			return line("red", "❌", fmt.Sprint(e.Err)), false
+		}
+		return line("red", "❌", i18n.T("common.error_in", e.Spec, e.Err)), false
+	case StepSkipped:
+		return line("yellow", "⚠️", fmt.Sprint(e.Err)), false
+	case ReadinessWaiting:
+		return line("yellow", "⏳", i18n.T("readiness.waiting", e.Spec, e.Detail)), true
+	case ReadinessReady:
+		return line("green", "💚", i18n.T("readiness.ready", e.Spec, e.Duration.Round(100*time.Millisecond))), true
+	case RollbackStarted:
+		return "\n" + line("yellow", "↩️", i18n.T("up.rolling_back")), true
+	case RollbackStepStarted:
+		return line("blue", "🚀", i18n.T("down.stopping", e.Spec)), true
+	case RollbackStepFailed:
+		return line("red", "❌", i18n.T("up.rollback_error", e.Spec, e.Err)), false
+	}
+	return "", true
+}
+
+// isDownLast identifica o "down --last", que anuncia sozinho qual execução está desfazendo.
+func isDownLast(e Event) bool {
+	return e.Operation == "down" && slices.Contains(e.Args, "--last")
+}
+
+func operationHeader(e Event) string {
+	switch e.Operation {
+	case "up":
+		return line("cyan", "🔄", i18n.T("up.starting_group", e.Target, e.Parallel)) + "\n"
+	case "down":
+		if isDownLast(e) {
+			return ""
+		}
+		volumeMsg := ""
+		if slices.Contains(e.Args, "-v") {
+			volumeMsg = i18n.T("down.removing_volumes")
+		}
+		if e.Target == "" {
+			return line("cyan", "⏹️", i18n.T("down.stopping_all", volumeMsg)) + "\n"
+		}
+		return line("cyan", "⏹️", i18n.T("down.stopping_group", e.Target, volumeMsg)) + "\n"
+	}
+	if action, ok := composeActions[e.Operation]; ok {
+		if e.Target == "" {
+			return line("cyan", "🔄", i18n.T(action.Messages+".all")) + "\n"
+		}
+		return line("cyan", "🔄", i18n.T(action.Messages+".target", e.Target, e.Parallel)) + "\n"
+	}
+	return ""
+}
+
+func operationFooter(e Event) string {
+	switch {
+	case e.Operation == "up":
+		return "\n" + line("green", "✨", i18n.T("up.group_ready"))
+	case isDownLast(e):
+		return "\n" + line("green", "✨", i18n.T("history.last_stopped")) + "\n"
+	case e.Operation == "down" && e.Target == "":
+		return "\n" + line("green", "✨", i18n.T("down.all_stopped")) + "\n"
+	case e.Operation == "down":
+		return "\n" + line("green", "✨", i18n.T("down.group_stopped", e.Target)) + "\n"
+	}
+	if action, ok := composeActions[e.Operation]; ok {
+		return "\n" + line("green", "✨", i18n.T(action.Messages+".done")) + "\n"
+	}
+	return ""
+}
+
+// stepMessage descreve o início de um step: "Iniciando api", "Parando db"...
+func stepMessage(e Event) string {
+	switch e.Operation {
+	case "up":
+		return i18n.T("up.starting", e.Spec)
+	case "down":
+		return i18n.T("down.stopping", e.Spec)
+	}
+	if action, ok := composeActions[e.Operation]; ok {
+		return i18n.T(action.Messages+".spec", e.Spec)
+	}
+	return e.Spec
+}
+
+// SummaryReporter mostra, ao fim de cada operação com mais de um spec, uma tabela com o resultado e
+// a duração de cada um.
+type SummaryReporter struct {
+	mu    sync.Mutex
+	out   io.Writer
+	steps []summaryStep // Na ordem em que começaram
+}
+
+type summaryStep struct {
+	spec     string
+	kind     EventKind // StepStarted enquanto o spec não termina
+	duration time.Duration
+}
+
+func NewSummaryReporter(out io.Writer) *SummaryReporter {
+	return &SummaryReporter{out: out}
+}
+
+func (s *SummaryReporter) Report(e Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch e.Kind {
+	case OperationStarted:
+		s.steps = nil
+	case StepStarted, StepSkipped:
+		s.steps = append(s.steps, summaryStep{spec: e.Spec, kind: e.Kind})
+	case StepFinished, StepFailed:
+		for i := range s.steps {
+			if s.steps[i].spec == e.Spec && s.steps[i].kind == StepStarted {
+				s.steps[i].kind, s.steps[i].duration = e.Kind, e.Duration
+				break
+			}
+		}
+	case OperationFinished:
+		if len(s.steps) > 1 {
+			s.print(e)
+		}
+		s.steps = nil
+	}
+}
+
+// summaryLabels guarda a chave do catálogo e a cor de cada resultado na tabela.
+var summaryLabels = map[EventKind]struct{ key, color string }{
+	StepFinished: {"summary.ok", "green"},
+	StepFailed:   {"summary.failed", "red"},
+	StepSkipped:  {"summary.skipped", "yellow"},
+	StepStarted:  {"summary.interrupted", "yellow"},
+}
+
+func (s *SummaryReporter) print(e Event) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n%s%s\n", utils.Icon("cyan", "📋"), i18n.T("summary.title", strings.TrimSpace(e.Operation+" "+e.Target)))
+
+	// O resultado fica na última coluna: as cores não atrapalham o alinhamento do tabwriter
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, i18n.T("summary.header"))
+	for _, step := range s.steps {
+		duration := "-"
+		if step.kind == StepFinished || step.kind == StepFailed {
+			duration = step.duration.Round(100 * time.Millisecond).String()
+		}
+		label := summaryLabels[step.kind]
+		fmt.Fprintf(w, "%s\t%s\t%s\n", step.spec, duration, utils.Colorize(label.color, i18n.T(label.key)))
+	}
+	w.Flush()
+	fmt.Fprintf(&b, "%s\n\n", i18n.T("summary.total", e.Duration.Round(100*time.Millisecond)))
+
+	io.WriteString(s.out, b.String())
+}
+
+// JSONReporter escreve cada evento como uma linha JSON, para CI e outras ferramentas.
+type JSONReporter struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// jsonEvent é o formato de cada linha do JSONReporter.
+type jsonEvent struct {
+	Time       time.Time `json:"time"`
+	Event      EventKind `json:"event"`
+	Operation  string    `json:"operation"`
+	Target     string    `json:"target,omitempty"`
+	Spec       string    `json:"spec,omitempty"`
+	Args       []string  `json:"args,omitempty"`
+	Parallel   bool      `json:"parallel,omitempty"`
+	Wave       int       `json:"wave,omitempty"`
+	Waves      int       `json:"waves,omitempty"`
+	Specs      []string  `json:"specs,omitempty"`
+	Detail     string    `json:"detail,omitempty"`
+	DurationMs int64     `json:"durationMs,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	ExitCode   *int      `json:"exitCode,omitempty"` // Quando o compose terminou com erro
+	Output     string    `json:"output,omitempty"`   // Últimas linhas da saída do compose que falhou
+}
+
+func NewJSONReporter(out io.Writer) *JSONReporter {
+	return &JSONReporter{enc: json.NewEncoder(out)}
+}
+
+func (j *JSONReporter) Report(e Event) {
+	event := jsonEvent{
+		Time:       e.Time,
+		Event:      e.Kind,
+		Operation:  e.Operation,
+		Target:     e.Target,
+		Spec:       e.Spec,
+		Args:       e.Args,
+		Parallel:   e.Parallel,
+		Wave:       e.Wave,
+		Waves:      e.Waves,
+		Specs:      e.Specs,
+		Detail:     e.Detail,
+		DurationMs: e.Duration.Milliseconds(),
+	}
+	if e.Err != nil {
+		event.Error = e.Err.Error()
+	}
+	var composeErr *ComposeError
+	if errors.As(e.Err, &composeErr) && composeErr.ExitCode >= 0 {
+		event.ExitCode = &composeErr.ExitCode
+		event.Output = composeErr.Output
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.enc.Encode(event)
+}
@@ -0,0 +1,163 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// upEvents simula um "up" em que db sobe, broken falha e api é ignorado por depender de broken.
+func upEvents() []Event {
+	failure := &ComposeError{Project: "broken", Command: "docker", Args: []string{"compose", "up", "-d"}, ExitCode: 3, Output: "boom"}
+	return []Event{
+		{Kind: OperationStarted, Operation: "up", Target: "dev", Parallel: true},
+		{Kind: WaveStarted, Operation: "up", Target: "dev", Wave: 1, Waves: 2, Specs: []string{"db", "broken"}},
+		{Kind: StepStarted, Operation: "up", Target: "dev", Spec: "db"},
+		{Kind: StepStarted, Operation: "up", Target: "dev", Spec: "broken"},
+		{Kind: StepFinished, Operation: "up", Target: "dev", Spec: "db", Duration: 1200 * time.Millisecond},
+		{Kind: StepFailed, Operation: "up", Target: "dev", Spec: "broken", Duration: 300 * time.Millisecond, Err: failure},
+		{Kind: StepSkipped, Operation: "up", Target: "dev", Spec: "api", Err: errors.New("api ignorado")},
+		{Kind: OperationFinished, Operation: "up", Target: "dev", Duration: 1500 * time.Millisecond, Err: ErrPartialFailure},
+	}
+}
+
+func report(r Reporter, events []Event) {
+	for _, e := range events {
+		r.Report(e)
+	}
+}
+
+func TestConsoleReporter(t *testing.T) {
+	var out strings.Builder
+	report(NewConsoleReporter(&out, false), upEvents())
+	for _, expected := range []string{"Iniciando grupo 'dev'", "Etapa 1/2: db, broken", "Iniciando db", "db pronto!", "Erro em broken", "api ignorado"} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("expected %q in the output:\n%s", expected, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "Grupo pronto") {
+		t.Errorf("expected no success message for a failed operation:\n%s", out.String())
+	}
+
+	// quiet: apenas falhas e serviços ignorados
+	out.Reset()
+	report(NewConsoleReporter(&out, true), upEvents())
+	if strings.Contains(out.String(), "Iniciando") || !strings.Contains(out.String(), "Erro em broken") || !strings.Contains(out.String(), "api ignorado") {
+		t.Errorf("unexpected quiet output:\n%s", out.String())
+	}
+}
+
+func TestConsoleReporterNotStarted(t *testing.T) {
+	var out strings.Builder
+	report(NewConsoleReporter(&out, true), []Event{
+		{Kind: OperationStarted, Operation: "up", Target: "dev"},
+		{Kind: StepSkipped, Operation: "up", Target: "dev", Spec: "b", Err: errNotStarted},
+		{Kind: StepSkipped, Operation: "up", Target: "dev", Spec: "c", Err: errNotStarted},
+		{Kind: OperationFinished, Operation: "up", Target: "dev", Err: ErrComposeFailed},
+	})
+	if strings.TrimSpace(out.String()) != "⏹️ Execução interrompida; não iniciados: b, c" {
+		t.Errorf("expected the specs not started in a single line:\n%s", out.String())
+	}
+}
+
+func TestSummaryReporter(t *testing.T) {
+	var out strings.Builder
+	report(NewSummaryReporter(&out), upEvents())
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 6 || !strings.Contains(lines[0], "Resumo: up dev") || lines[5] != "Total: 1.5s" {
+		t.Fatalf("unexpected summary:\n%s", out.String())
+	}
+	expected := [][]string{{"db", "1.2s", "ok"}, {"broken", "300ms", "falhou"}, {"api", "-", "ignorado"}}
+	for i, fields := range expected {
+		if got := strings.Fields(lines[i+2]); strings.Join(got, " ") != strings.Join(fields, " ") {
+			t.Errorf("unexpected row %d: %q", i, lines[i+2])
+		}
+	}
+
+	// Uma operação com um único spec não precisa de tabela
+	out.Reset()
+	report(NewSummaryReporter(&out), []Event{
+		{Kind: OperationStarted, Operation: "restart", Target: "db"},
+		{Kind: StepStarted, Operation: "restart", Target: "db", Spec: "db"},
+		{Kind: StepFinished, Operation: "restart", Target: "db", Spec: "db"},
+		{Kind: OperationFinished, Operation: "restart", Target: "db"},
+	})
+	if out.Len() != 0 {
+		t.Errorf("expected no summary for a single spec:\n%s", out.String())
+	}
+}
+
+func TestJSONReporter(t *testing.T) {
+	var out strings.Builder
+	report(NewJSONReporter(&out), upEvents())
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != len(upEvents()) {
+		t.Fatalf("expected one line per event, got:\n%s", out.String())
+	}
+
+	var failed struct {
+		Event      string `json:"event"`
+		Spec       string `json:"spec"`
+		DurationMs int64  `json:"durationMs"`
+		ExitCode   int    `json:"exitCode"`
+		Output     string `json:"output"`
+		Error      string `json:"error"`
+	}
+	if err := json.Unmarshal([]byte(lines[5]), &failed); err != nil {
+		t.Fatalf("invalid JSON line %q: %v", lines[5], err)
+	}
+	if failed.Event != "step_failed" || failed.Spec != "broken" || failed.DurationMs != 300 || failed.ExitCode != 3 || failed.Output != "boom" || failed.Error == "" {
+		t.Errorf("unexpected step_failed line: %s", lines[5])
+	}
+}
+
+func TestLiveReporter(t *testing.T) {
+	var out syncBuffer
+	live := NewLiveReporter(&out)
+
+	live.Report(Event{Kind: OperationStarted, Operation: "up", Target: "dev", Parallel: true})
+	live.Report(Event{Kind: StepStarted, Operation: "up", Target: "dev", Spec: "db", Time: time.Now()})
+	if !strings.HasSuffix(out.String(), " db  0s\n") {
+		t.Errorf("expected a live line for db:\n%q", out.String())
+	}
+
+	// Com linhas ao vivo na tela, uma linha incompleta espera pelo resto e depois é escrita acima delas
+	before := len(out.String())
+	live.Write([]byte("saída "))
+	if len(out.String()) != before {
+		t.Errorf("expected the partial line to be held:\n%q", out.String())
+	}
+	live.Write([]byte("do compose\n"))
+	if !strings.Contains(out.String(), "\033[1A\r\033[Jsaída do compose\n") {
+		t.Errorf("expected the live line to be cleared before the output:\n%q", out.String())
+	}
+
+	// O stderr vai para o próprio destino, mas também apaga e redesenha as linhas ao vivo
+	var stderr strings.Builder
+	errOut := live.Wrap(&stderr)
+	before = len(out.String())
+	errOut.Write([]byte("aviso do compose\n"))
+	if stderr.String() != "aviso do compose\n" {
+		t.Errorf("expected the warning on stderr, got %q", stderr.String())
+	}
+	if redraw := out.String()[before:]; !strings.HasPrefix(redraw, "\033[1A\r\033[J") || !strings.Contains(redraw, " db  ") || strings.Contains(redraw, "aviso") {
+		t.Errorf("expected only the live line to be redrawn on stdout, got %q", redraw)
+	}
+
+	live.Report(Event{Kind: StepFinished, Operation: "up", Target: "dev", Spec: "db", Duration: time.Second})
+	live.Report(Event{Kind: OperationFinished, Operation: "up", Target: "dev"})
+	if !strings.Contains(out.String(), "db (1s)\n") || !strings.HasSuffix(out.String(), "Grupo pronto!\n") {
+		t.Errorf("unexpected final output:\n%q", out.String())
+	}
+
+	// Fora de uma operação a escrita é direta
+	before = len(out.String())
+	live.Write([]byte("status"))
+	if out.String()[before:] != "status" {
+		t.Errorf("expected a direct write, got %q", out.String()[before:])
+	}
+}
@@ -0,0 +1,172 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/Disneyjr/dcm/internal/state"
+	"github.com/Disneyjr/dcm/internal/workspace"
+)
+
+const (
+	// DefaultTailLines é quantas linhas finais da saída capturada são exibidas quando um comando falha.
+	DefaultTailLines = 20
+
+	// DefaultGracePeriod é quanto tempo um comando tem para encerrar depois de ser interrompido
+	// (Ctrl+C ou timeout) antes de ser morto.
+	DefaultGracePeriod = 10 * time.Second
+)
+
+// Runner executa as operações sobre um workspace. A configuração fica nos campos, e não em
+// variáveis do pacote, para que vários Runners (o CLI, o painel e programas que usam pkg/dcm)
+// convivam no mesmo processo. Crie com NewRunner.
+type Runner struct {
+	Workspace *workspace.Workspace
+	Engine    Engine
+
+	// Out recebe o progresso, os dados e a saída do compose; ErrOut, o stderr do compose quando ele
+	// não é capturado. Nos grupos paralelos os dois recebem escritas de várias goroutines.
+	Out    io.Writer
+	ErrOut io.Writer
+
+	// DataOut recebe os documentos json e yaml de status, list, inspect, config, history e task
+	// (nil usa Out). Assim eles continuam na saída padrão quando o progresso vai para outro lugar.
+	DataOut io.Writer
+
+	DryRun   bool
+	Verbose  bool // Mostra cada comando executado e a saída do compose mesmo nas execuções em paralelo
+	Quiet    bool // Captura a saída do compose também no "up" em sequência; erros e dados continuam aparecendo
+	FailFast bool // Interrompe o "up" na primeira falha, mesmo em grupos com onFailure "continue"
+
+	// Jobs limita quantos serviços são executados ao mesmo tempo nos grupos paralelos (0 = sem
+	// limite). Quando definido, tem precedência sobre o maxParallel dos grupos.
+	Jobs int
+
+	OutputFormat string // text, json ou yaml: como status, list, inspect e history imprimem
+	TailLines    int
+	GracePeriod  time.Duration
+
+	// LogDir, quando preenchido, recebe um arquivo com a saída completa de cada comando do compose
+	// (o CLI usa .dcm/logs/<data-hora> com a flag --save-logs).
+	LogDir string
+
+	// Reporter recebe os eventos das operações e é quem mostra o progresso (nil não mostra nada).
+	// É chamado de várias goroutines ao mesmo tempo nos grupos paralelos.
+	Reporter Reporter
+}
+
+// NewRunner cria um Runner com os padrões do CLI: engine "docker compose", saída no terminal e
+// formato texto.
+func NewRunner(ws *workspace.Workspace) *Runner {
+	return &Runner{
+		Workspace:    ws,
+		Engine:       DefaultEngine,
+		Out:          os.Stdout,
+		ErrOut:       os.Stderr,
+		OutputFormat: OutputText,
+		TailLines:    DefaultTailLines,
+		GracePeriod:  DefaultGracePeriod,
+	}
+}
+
+func (r *Runner) printf(format string, a ...any) {
+	fmt.Fprintf(r.Out, format, a...)
+}
+
+// progressf imprime uma mensagem de progresso, a menos que Quiet esteja ativo.
+func (r *Runner) progressf(format string, a ...any) {
+	if !r.Quiet {
+		r.printf(format, a...)
+	}
+}
+
+// EventKind identifica o tipo de um Event.
+type EventKind string
+
+const (
+	OperationStarted    EventKind = "operation_started"
+	OperationFinished   EventKind = "operation_finished"
+	WaveStarted         EventKind = "wave_started" // Apenas nas execuções em paralelo
+	StepStarted         EventKind = "step_started"
+	StepFinished        EventKind = "step_finished"
+	StepFailed          EventKind = "step_failed"
+	StepSkipped         EventKind = "step_skipped" // Dependência falhou ou a execução foi interrompida antes
+	ReadinessWaiting    EventKind = "readiness_waiting"
+	ReadinessReady      EventKind = "readiness_ready"
+	RollbackStarted     EventKind = "rollback_started"
+	RollbackStepStarted EventKind = "rollback_step_started"
+	RollbackStepFailed  EventKind = "rollback_step_failed"
+)
+
+// Event descreve o andamento de uma operação (up, down, restart, pull ou build) e de cada spec
+// executado por ela.
+type Event struct {
+	Kind      EventKind
+	Operation string
+	Target    string // Vazio quando a operação vale para o workspace inteiro
+	Spec      string // Nos eventos de step, readiness e rollback
+	Time      time.Time
+	Duration  time.Duration // Nos eventos de término e em ReadinessReady
+	Err       error         // Nas falhas, em StepSkipped e no OperationFinished de uma operação que falhou
+
+	Args     []string // Em OperationStarted: os argumentos extras da operação (ex: --build, -v)
+	Parallel bool     // Em OperationStarted
+	Wave     int      // Em WaveStarted: a etapa atual, a partir de 1, e o total de etapas
+	Waves    int
+	Specs    []string // Em WaveStarted e RollbackStarted
+	Detail   string   // Em ReadinessWaiting: o tipo de readiness
+}
+
+func (r *Runner) emit(e Event) {
+	if r.Reporter == nil {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	r.Reporter.Report(e)
+}
+
+// startRun anuncia o início de uma operação e devolve o registro que finishRun grava no histórico.
+func (r *Runner) startRun(command, target string, args []string, parallel bool) *state.Run {
+	run := &state.Run{Command: command, Target: target, Args: args, Started: time.Now()}
+	r.emit(Event{Kind: OperationStarted, Operation: command, Target: target, Time: run.Started, Args: args, Parallel: parallel})
+	return run
+}
+
+// event monta um evento da operação de run.
+func (r *Runner) event(run *state.Run, kind EventKind, spec string) Event {
+	return Event{Kind: kind, Operation: run.Command, Target: run.Target, Spec: spec}
+}
+
+// finishRun registra a execução no histórico e anuncia o seu término.
+func (r *Runner) finishRun(run *state.Run, err error) {
+	r.recordRun(*run, err)
+	r.emit(Event{Kind: OperationFinished, Operation: run.Command, Target: run.Target, Duration: time.Since(run.Started), Err: err})
+}
+
+// step executa fn para um spec da operação, anunciando o início e o resultado.
+func (r *Runner) step(run *state.Run, spec string, fn func() error) error {
+	start := time.Now()
+	started := r.event(run, StepStarted, spec)
+	started.Time = start
+	r.emit(started)
+
+	err := fn()
+	finished := r.event(run, StepFinished, spec)
+	if err != nil {
+		finished.Kind = StepFailed
+	}
+	finished.Duration, finished.Err = time.Since(start), err
+	r.emit(finished)
+	return err
+}
+
+// skip anuncia um spec que não foi executado e o motivo.
+func (r *Runner) skip(run *state.Run, spec string, err error) {
+	e := r.event(run, StepSkipped, spec)
+	e.Err = err
+	r.emit(e)
+}
@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Disneyjr/dcm/internal/workspace"
+)
+
+func TestRunnerEvents(t *testing.T) {
+	parallelFalse := false
+	ws := &workspace.Workspace{
+		Projects: map[string]workspace.Project{
+			"db":     {Path: "./db"},
+			"broken": {Path: "./broken", Readiness: &workspace.Readiness{Type: "invalid"}},
+			"api":    {Path: "./api", DependsOn: []string{"broken"}},
+		},
+		Groups: map[string]workspace.Group{
+			"dev": {Services: []string{"db", "broken", "api"}, Parallel: &parallelFalse},
+		},
+	}
+
+	var mu sync.Mutex
+	var events []Event
+	r, _ := dryRunner(ws)
+	r.Reporter = ReporterFunc(func(e Event) {
+		mu.Lock()
+		events = append(events, e)
+		mu.Unlock()
+	})
+
+	err := r.UpGroup(context.Background(), "dev")
+	if !errors.Is(err, ErrPartialFailure) {
+		t.Fatalf("expected partial failure, got %v", err)
+	}
+
+	var got []string
+	for _, e := range events {
+		if e.Operation != "up" || e.Target != "dev" || e.Time.IsZero() {
+			t.Errorf("unexpected event: %+v", e)
+		}
+		got = append(got, strings.TrimSpace(string(e.Kind)+" "+e.Spec))
+	}
+	expected := []string{
+		"operation_started",
+		"step_started db", "step_finished db",
+		"step_started broken", "step_failed broken",
+		"step_skipped api",
+		"operation_finished",
+	}
+	if strings.Join(got, ", ") != strings.Join(expected, ", ") {
+		t.Errorf("unexpected events:\n%s", strings.Join(got, "\n"))
+	}
+
+	last := events[len(events)-1]
+	if !errors.Is(last.Err, ErrPartialFailure) {
+		t.Errorf("expected operation_finished to carry the error, got %v", last.Err)
+	}
+	if skipped := events[5]; skipped.Err == nil || !strings.Contains(skipped.Err.Error(), "broken") {
+		t.Errorf("expected skip reason to name the failed dependency, got %v", skipped.Err)
+	}
+}
@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/Disneyjr/dcm/internal/i18n"
+	"github.com/Disneyjr/dcm/internal/workspace"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultComposeFiles são os nomes procurados pelo compose quando o projeto não define composeFiles.
+var defaultComposeFiles = []string{"compose.yaml", "compose.yml", "docker-compose.yaml", "docker-compose.yml"}
+
+// projectComposeFiles retorna os arquivos compose usados pelo projeto, com caminhos absolutos.
+func projectComposeFiles(project workspace.Project) []string {
+	if len(project.ComposeFiles) > 0 {
+		files := make([]string, len(project.ComposeFiles))
+		for i, file := range project.ComposeFiles {
+			if !filepath.IsAbs(file) {
+				file = filepath.Join(project.Path, file)
+			}
+			files[i] = file
+		}
+		return files
+	}
+
+	for _, name := range defaultComposeFiles {
+		path := filepath.Join(project.Path, name)
+		if _, err := os.Stat(path); err == nil {
+			return []string{path}
+		}
+	}
+	return nil
+}
+
+// ComposeServices lê os nomes dos serviços dos arquivos compose do projeto, sem executar o engine.
+// Serviços configurados apenas no workspace (campo "services") também são incluídos.
+func ComposeServices(project workspace.Project) ([]string, error) {
+	seen := make(map[string]bool)
+	for name := range project.Services {
+		seen[name] = true
+	}
+
+	for _, file := range projectComposeFiles(project) {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		var doc struct {
+			Services map[string]yaml.Node `yaml:"services"`
+		}
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, i18n.Errorf("services.read_error", filepath.Base(file), err)
+		}
+		for name := range doc.Services {
+			seen[name] = true
+		}
+	}
+
+	services := make([]string, 0, len(seen))
+	for name := range seen {
+		services = append(services, name)
+	}
+	sort.Strings(services)
+	return services, nil
+}
+
+// TargetNames lista tudo o que é aceito como alvo: grupos, projetos e specs "projeto:serviço".
+// Projetos cujo compose não pode ser lido aparecem sem os seus serviços.
+func TargetNames(ws *workspace.Workspace) []string {
+	names := sortedGroupNames(ws)
+	for _, projectName := range sortedProjectNames(ws) {
+		names = append(names, projectName)
+		services, err := ComposeServices(ws.Projects[projectName])
+		if err != nil {
+			continue
+		}
+		for _, service := range services {
+			names = append(names, projectName+":"+service)
+		}
+	}
+	return names
+}
+
+// GroupNames lista os grupos do workspace em ordem alfabética.
+func GroupNames(ws *workspace.Workspace) []string {
+	return sortedGroupNames(ws)
+}
@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Disneyjr/dcm/internal/workspace"
+)
+
+func TestComposeServices(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "docker-compose.yml"), []byte("services:\n  web:\n    image: nginx\n  db:\n    image: postgres\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "compose.dev.yml"), []byte("services:\n  debug:\n    image: busybox\n"), 0644)
+
+	// Sem composeFiles, usa o arquivo padrão encontrado no diretório
+	services, err := ComposeServices(workspace.Project{Path: dir})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(services, []string{"db", "web"}) {
+		t.Errorf("unexpected services: %v", services)
+	}
+
+	// Com composeFiles, une os serviços de todos os arquivos e os configurados no workspace
+	project := workspace.Project{
+		Path:         dir,
+		ComposeFiles: []string{"docker-compose.yml", "compose.dev.yml"},
+		Services:     map[string]workspace.ServiceOptions{"worker": {}},
+	}
+	services, err = ComposeServices(project)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(services, []string{"db", "debug", "web", "worker"}) {
+		t.Errorf("unexpected services: %v", services)
+	}
+}
+
+func TestTargetNames(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "compose.yaml"), []byte("services:\n  web: {}\n"), 0644)
+
+	ws := &workspace.Workspace{
+		Projects: map[string]workspace.Project{
+			"api":     {Path: dir},
+			"missing": {Path: filepath.Join(dir, "nope")},
+		},
+		Groups: map[string]workspace.Group{"dev": {Services: []string{"api"}}},
+	}
+
+	expected := []string{"dev", "api", "api:web", "missing"}
+	if names := TargetNames(ws); !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected %v, got %v", expected, names)
+	}
+}
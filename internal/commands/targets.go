@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/Disneyjr/dcm/internal/i18n"
+	"github.com/Disneyjr/dcm/internal/workspace"
+)
+
+// resolveTargets aceita um grupo (com extends), um projeto ou um spec "projeto:serviço".
+// Um alvo vazio representa todos os projetos do workspace, executados em sequência.
+func resolveTargets(ws *workspace.Workspace, target string) ([]string, bool, error) {
+	if target == "" {
+		return sortedProjectNames(ws), false, nil
+	}
+
+	if _, exists := ws.Groups[target]; exists {
+		return resolveGroupServices(ws, target, make(map[string]bool))
+	}
+
+	projectName, _ := splitServiceSpec(target)
+	if _, exists := ws.Projects[projectName]; exists {
+		return []string{target}, true, nil
+	}
+
+	return nil, true, i18n.Errorf("workspace.target_not_found", target)
+}
+
+// composeAction descreve um comando compose aplicado a cada serviço de um alvo.
+type composeAction struct {
+	Messages string // Prefixo das chaves do catálogo: <Messages>.all, .target, .spec e .done
+	Args     []string
+}
+
+var (
+	restartAction = composeAction{Messages: "restart", Args: []string{"restart"}}
+	pullAction    = composeAction{Messages: "pull", Args: []string{"pull"}}
+	buildAction   = composeAction{Messages: "build", Args: []string{"build"}}
+)
+
+// composeActions localiza a ação de cada operação, para que os reporters encontrem as mensagens.
+var composeActions = map[string]composeAction{
+	"restart": restartAction,
+	"pull":    pullAction,
+	"build":   buildAction,
+}
+
+func (r *Runner) runComposeAction(ctx context.Context, target string, action composeAction, extraArgs ...string) (err error) {
+	ws := r.Workspace
+	services, parallel, err := resolveTargets(ws, target)
+	if err != nil {
+		return err
+	}
+
+	plan, err := planStartup(ws, services)
+	if err != nil {
+		return err
+	}
+	plan = plan.targetsOnly()
+
+	run := r.startRun(action.Args[0], target, extraArgs, parallel)
+	defer func() { r.finishRun(run, err) }()
+
+	result := r.executePlan(ctx, run, plan, planOptions{Parallel: parallel, Limit: concurrencyLimit(ws, target, r.Jobs)}, func(spec string) error {
+		projectName, targetService := splitServiceSpec(spec)
+		project, exists := ws.Projects[projectName]
+		if !exists {
+			return i18n.Errorf("workspace.project_not_found", projectName)
+		}
+
+		args := append(append([]string{}, action.Args...), extraArgs...)
+		if targetService != "" {
+			args = append(args, targetService)
+		}
+		if err := r.runCompose(ctx, project, spec, args, true); err != nil {
+			return i18n.Errorf("action.error_in", spec, err)
+		}
+		return nil
+	})
+
+	run.Specs = result.Succeeded
+	if err := interruption(ctx); err != nil {
+		return err
+	}
+	if result.failed() {
+		return result.failure(i18n.Errorf("action.some_failed"))
+	}
+	return nil
+}
+
+// RestartGroup reinicia um grupo, projeto ou spec; vazio reinicia o workspace inteiro.
+func (r *Runner) RestartGroup(ctx context.Context, target string) error {
+	return r.runComposeAction(ctx, target, restartAction)
+}
+
+func (r *Runner) PullGroup(ctx context.Context, target string) error {
+	return r.runComposeAction(ctx, target, pullAction)
+}
+
+func (r *Runner) BuildGroup(ctx context.Context, target string, extraArgs ...string) error {
+	return r.runComposeAction(ctx, target, buildAction, extraArgs...)
+}
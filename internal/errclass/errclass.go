@@ -0,0 +1,18 @@
+// Package errclass associa uma classe (um erro sentinela, como workspace.ErrInvalid) a um erro
+// sem alterar a mensagem original. O CLI usa a classe com errors.Is para escolher o código de saída.
+package errclass
+
+// classError mantém a mensagem do erro original e acrescenta a classe à cadeia do errors.Is.
+type classError struct {
+	class error
+	err   error
+}
+
+func (e *classError) Error() string   { return e.err.Error() }
+func (e *classError) Unwrap() []error { return []error{e.class, e.err} }
+
+// Wrap devolve err com a classe acrescentada: errors.Is(Wrap(class, err), class) é verdadeiro e
+// Error() continua sendo o de err.
+func Wrap(class, err error) error {
+	return &classError{class: class, err: err}
+}
@@ -0,0 +1,19 @@
+package errclass
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWrap(t *testing.T) {
+	class := errors.New("class")
+	cause := errors.New("cause")
+
+	err := Wrap(class, cause)
+	if err.Error() != "cause" {
+		t.Errorf("expected the original message, got %q", err.Error())
+	}
+	if !errors.Is(err, class) || !errors.Is(err, cause) {
+		t.Error("expected both the class and the cause in the errors.Is chain")
+	}
+}
@@ -0,0 +1,351 @@
+package i18n
+
+// en é o catálogo em inglês; precisa ter exatamente as mesmas chaves de ptBR.
+var en = Catalog{
+	"action.error_in":    "error in %s: %w",
+	"action.some_failed": "some services failed",
+
+	"args.color_mode":       "<auto|always|never>",
+	"args.exec":             "<project:service> -- <command>",
+	"args.file":             "<file>",
+	"args.file_or_dir":      "<file|dir>",
+	"args.group":            "<group>",
+	"args.init_format":      "<json|yaml|toml>",
+	"args.language":         "<language>",
+	"args.n":                "<n>",
+	"args.optional_command": "[command]",
+	"args.optional_target":  "[target]",
+	"args.output_format":    "<text|json|yaml>",
+	"args.progress_mode":    "<auto|text|live|json>",
+	"args.regex":            "<regex>",
+	"args.shell":            "<shell>",
+	"args.target":           "<target>",
+	"args.task":             "[project] [task] [-- args]",
+	"args.time":             "<t>",
+
+	"build.all":    "Building all services...",
+	"build.done":   "All built!",
+	"build.spec":   "Building %s",
+	"build.target": "Building '%s' (parallel=%v)...",
+
+	"cli.interrupting":           "Interrupting... (Ctrl+C again to force)",
+	"cli.invalid_jobs":           "invalid value for --jobs: %s (use a number greater than zero)",
+	"cli.invalid_progress":       "invalid value for --progress: '%s' (use: %s)",
+	"cli.invalid_value":          "invalid value for --%s: %s",
+	"cli.last_with_target":       "--last does not accept a target",
+	"cli.missing_args":           "not enough arguments: dcm %s %s",
+	"cli.missing_value":          "flag %s requires a value %s",
+	"cli.too_many_args":          "too many arguments for %s: %s",
+	"cli.unexpected_passthrough": "command '%s' does not accept arguments after \"--\"",
+	"cli.unknown_command":        "unknown command: %s (use 'dcm help')",
+	"cli.unknown_command_flag":   "unknown flag for %s: %s (use 'dcm help %s')",
+	"cli.unknown_flag":           "unknown flag: %s",
+	"cli.verbose_quiet":          "--verbose and --quiet cannot be used together",
+
+	"cmd.build.summary":      "Build the target's images",
+	"cmd.completion.summary": "Generate the shell completion script (bash, zsh, fish, powershell)",
+	"cmd.config.summary":     "Show the resolved workspace",
+	"cmd.down.summary":       "Stop the target (or all services)",
+	"cmd.exec.summary":       "Run a command in a running service",
+	"cmd.help.summary":       "Show general help or help for a command",
+	"cmd.history.summary":    "Previous runs of up, down, restart, pull and build",
+	"cmd.init.summary":       "Create an initial configuration",
+	"cmd.inspect.summary":    "Show the composition of a group",
+	"cmd.list.summary":       "List projects and groups",
+	"cmd.logs.summary":       "Project logs, prefixed by project",
+	"cmd.pull.summary":       "Pull the target's images",
+	"cmd.restart.summary":    "Restart the target (or all)",
+	"cmd.run.summary":        "Run a command in a new container of the service",
+	"cmd.status.summary":     "Service status",
+	"cmd.task.summary":       "List or run project tasks",
+	"cmd.ui.summary":         "Interactive dashboard with the state of the services",
+	"cmd.up.summary":         "Start a group, project or project:service",
+	"cmd.validate.summary":   "Validate the workspace file",
+	"cmd.version.summary":    "Show the version",
+
+	"common.error":    "Error: %v",
+	"common.error_in": "Error in %s: %v",
+
+	"completion.unsupported_shell": "unsupported shell: %s (use: %s)",
+
+	"compose.error":       "error: %v",
+	"compose.exit_code":   "error: '%s' exited with code %d",
+	"compose.full_log":    "Full log: %s",
+	"compose.interrupted": "error: '%s' interrupted",
+	"compose.output_tail": "Output of %s (last %d lines):",
+	"compose.timed_out":   "error: '%s' timed out",
+
+	"dotenv.invalid_line": "invalid line %d: expected KEY=value",
+	"dotenv.read_error":   "error reading %s: %w",
+
+	"down.all_stopped":           "All stopped!",
+	"down.alphabetical_order":    "%v (using alphabetical order)",
+	"down.group_services_failed": "%d service(s) of group '%s' failed to stop",
+	"down.group_stopped":         "Group '%s' stopped!",
+	"down.projects_failed":       "%d project(s) failed to stop",
+	"down.removing_volumes":      " and removing volumes",
+	"down.stopping":              "Stopping %s",
+	"down.stopping_all":          "Stopping all services%s...",
+	"down.stopping_group":        "Stopping group '%s'%s...",
+
+	"engine.compose_file_not_found": "compose file not found: %s",
+	"engine.empty_profile":          "empty profile",
+	"engine.env_file_not_found":     "envFile not found: %s",
+	"engine.invalid_project_name":   "invalid projectName '%s' (use lowercase letters, digits, '-' and '_')",
+	"engine.none":                   "no compose engine found (docker compose, docker-compose, podman-compose or nerdctl compose)",
+	"engine.unknown":                "unknown engine '%s' (use: %s)",
+
+	"errors.compose_failed":  "compose failed",
+	"errors.interrupted":     "operation interrupted",
+	"errors.partial_failure": "partial failure",
+
+	"exec.error":            "error running the command: %v",
+	"exec.exit_code":        "the command exited with code %d",
+	"exec.missing_command":  "provide the command after \"--\"",
+	"exec.service_choices":  "project '%s' has more than one service; use \"project:service\" (%s)",
+	"exec.service_required": "project '%s' has no services; use \"project:service\"",
+
+	"flag.build":     "Rebuild the images before starting",
+	"flag.color":     "Colors in the output: auto (terminals only; honors NO_COLOR and FORCE_COLOR), always or never",
+	"flag.dry-run":   "Show the commands that would run, without running them",
+	"flag.fail-fast": "Stop at the first failure (same as onFailure: stop)",
+	"flag.file":      "Merge another file over the workspace (repeatable)",
+	"flag.follow":    "Follow the logs until Ctrl+C",
+	"flag.format":    "Format of the created file (default: json)",
+	"flag.grep":      "Show only lines matching the regex",
+	"flag.jobs":      "Maximum number of services run at the same time (overrides the groups' maxParallel)",
+	"flag.lang":      "Message language (pt-BR, en); the default comes from LC_ALL, LC_MESSAGES or LANG",
+	"flag.last":      "Stop exactly what the last 'up' started",
+	"flag.limit":     "How many runs to show (default: 20, 0 = all)",
+	"flag.no-cache":  "Do not use the cache when building images",
+	"flag.output":    "Output format for status, list and inspect",
+	"flag.plain":     "Output without emojis, text only",
+	"flag.progress":  "Progress of up, down, restart, pull and build: auto (live in terminals), text, live or json (one JSON line per event; everything else goes to stderr)",
+	"flag.pull":      "Always try to pull newer versions of the base images",
+	"flag.quiet":     "Show only errors and data output",
+	"flag.save-logs": "Save the full output of each compose command in .dcm/logs",
+	"flag.since":     "Logs since a time or duration (e.g. 10m)",
+	"flag.sources":   "Show the source file of each value",
+	"flag.tail":      "Number of lines per container",
+	"flag.verbose":   "Show every command run and all compose output",
+	"flag.volumes":   "Also remove volumes",
+	"flag.workspace": "Use this workspace instead of searching parent directories",
+
+	"graph.cycle":         "dependency cycle detected: %s",
+	"graph.dependency_of": "dependency of '%s': %w",
+
+	"group.inheritance_cycle": "inheritance cycle detected in group '%s'",
+
+	"help.command_hint":        "Use 'dcm help <command>' to see the options of a command.",
+	"help.commands":            "Commands:",
+	"help.global_options":      "Global options:",
+	"help.options":             "Options:",
+	"help.options_placeholder": "[options]",
+	"help.usage":               "Usage: %s",
+	"help.usage_line":          "<command> [options]",
+	"help.usage_title":         "Usage:",
+	"help.version":             "Version: %s",
+
+	"history.all_targets":        "(all)",
+	"history.empty":              "No runs recorded in this workspace.",
+	"history.header":             "WHEN\tCOMMAND\tTARGET\tRESULT\tDURATION\tSERVICES",
+	"history.last_stopped":       "Services from the last 'up' stopped!",
+	"history.no_up":              "no 'up' recorded in this workspace (see 'dcm history')",
+	"history.nothing_to_undo":    "everything the recorded 'up' runs started has already been stopped (see 'dcm history')",
+	"history.result_failed":      "failed",
+	"history.result_interrupted": "interrupted",
+	"history.result_ok":          "ok",
+	"history.result_partial":     "partial",
+	"history.stop_failed":        "%d service(s) failed to stop",
+	"history.stopping_last":      "Stopping what 'up %s' from %s started...",
+	"history.write_error":        "Could not write the history: %v",
+
+	"i18n.unknown_locale": "unknown language '%s' (use: %s)",
+
+	"init.create_error":   "error creating %s: %w",
+	"init.created":        "%s created successfully!",
+	"init.exists":         "%s already exists",
+	"init.invalid_format": "invalid format '%s' (use: json, yaml, toml)",
+
+	"inspect.all_services": "all",
+	"inspect.config":       "Configuration: %s, onFailure=%s",
+	"inspect.depends_on":   "Depends on: %s",
+	"inspect.path":         "Path: %s",
+	"inspect.service":      "Service: %s",
+	"inspect.services":     "Services in execution order:",
+	"inspect.title":        "Group inspection: %s",
+	"inspect.waves":        "Startup stages:",
+
+	"install.admin_required":   "ERROR: Administrator privileges required",
+	"install.binary_not_found": "binary '%s' not found in the current directory",
+	"install.chmod":            "Setting permissions...",
+	"install.chmod_error":      "error setting permissions: %w",
+	"install.copy_error":       "error copying file: %w",
+	"install.copying":          "Copying binary to %s",
+	"install.create_error":     "error creating destination (you may need to run as Administrator): %w",
+	"install.detected":         "Detected: %s",
+	"install.example":          "Example:",
+	"install.finish_error":     "error finishing copy: %w",
+	"install.found":            "Found at: %s",
+	"install.installing":       "Installing DCM globally...",
+	"install.manual_windows":   "Move dcm.exe to C:\\Windows\\System32\\ (run as Administrator)",
+	"install.not_in_path":      "DCM not found in PATH",
+	"install.open_error":       "error opening file: %w",
+	"install.pipe_error":       "error creating pipe: %w",
+	"install.run_as_admin":     "Right-click %s > 'Run as administrator'",
+	"install.source_found":     "Found: %s",
+	"install.success":          "DCM installed successfully!",
+	"install.sudo_error":       "error running sudo: %w",
+	"install.title":            "DCM - Global Installer",
+	"install.try_manually":     "Try running manually:",
+	"install.trying_sudo":      "Permission denied, trying with sudo...",
+	"install.unsupported_os":   "unsupported OS: %s",
+	"install.usage":            "Usage: put dcm in the current directory and run this installer.",
+	"install.usage_hint":       "You can use 'dcm' from any terminal/folder.",
+	"install.validating":       "Validating installation...",
+	"install.version_error":    "error running '%s version': %w\nOutput: %s",
+
+	"interpolate.invalid_variable": "invalid variable '${%s}'",
+	"interpolate.not_set":          "not set",
+	"interpolate.required":         "required variable %s: %s",
+	"interpolate.unclosed":         "'${' without matching '}' in \"%s\"",
+
+	"list.groups":   "Groups:",
+	"list.projects": "Projects:",
+
+	"live.waiting": "waiting for readiness (%s)",
+
+	"logs.dir_error":      "Could not create %s: %v",
+	"logs.failed":         "failed to get logs from: %s",
+	"logs.file_error":     "Could not create the log for %s: %v",
+	"logs.invalid_filter": "invalid filter '%s': %w",
+	"logs.stream_error":   "error: %w",
+
+	"messages.press_enter": "Press ENTER to exit...",
+
+	"output.invalid_format": "invalid output format '%s' (use: text, json, yaml)",
+
+	"plan.not_started": "not started",
+	"plan.skipped":     "%s skipped: dependency '%s' failed",
+	"plan.stopped":     "Execution stopped; not started: %s",
+	"plan.wave":        "Stage %d/%d: %s",
+
+	"pull.all":    "Pulling images for all services...",
+	"pull.done":   "All updated!",
+	"pull.spec":   "Pulling images for %s",
+	"pull.target": "Pulling images for '%s' (parallel=%v)...",
+
+	"readiness.container_state":  "%s is %s",
+	"readiness.dry_run":          "wait for %s (%s, timeout %s)",
+	"readiness.http_status":      "%s responded %d",
+	"readiness.interrupted":      "wait for %s interrupted: %w",
+	"readiness.invalid_interval": "invalid interval '%s'",
+	"readiness.invalid_ps":       "invalid compose ps output: %w",
+	"readiness.invalid_timeout":  "invalid timeout '%s'",
+	"readiness.no_containers":    "no running containers",
+	"readiness.ps_error":         "error querying containers: %w",
+	"readiness.ready":            "%s ready in %s",
+	"readiness.requires":         "readiness '%s' requires '%s'",
+	"readiness.timeout":          "%s was not ready within %s: %v",
+	"readiness.unknown_type":     "unknown readiness type '%s' (use: healthy, tcp, http, command)",
+	"readiness.waiting":          "Waiting for %s to be ready (%s)...",
+
+	"restart.all":    "Restarting all services...",
+	"restart.done":   "All restarted!",
+	"restart.spec":   "Restarting %s",
+	"restart.target": "Restarting '%s' (parallel=%v)...",
+
+	"services.read_error": "error reading %s: %w",
+
+	"state.invalid": "%s is invalid: %w",
+	"state.locked":  "%s is locked by another dcm (remove the file if none is running)",
+
+	"status.all":               "Status of all services:",
+	"status.group":             "Status of '%s':",
+	"status.project_not_found": "Project '%s' not found",
+
+	"summary.failed":      "failed",
+	"summary.header":      "SERVICE\tDURATION\tRESULT",
+	"summary.interrupted": "interrupted",
+	"summary.ok":          "ok",
+	"summary.skipped":     "skipped",
+	"summary.title":       "Summary: %s",
+	"summary.total":       "Total: %s",
+
+	"task.none":            "No tasks defined in the workspace",
+	"task.none_in_project": "project '%s' defines no tasks",
+	"task.not_found":       "task '%s' not found in project '%s' (available: %s)",
+	"task.requires":        "task requires the \"%s\" field",
+	"task.title":           "Tasks:",
+
+	"terminal.invalid_color": "invalid value for --color: '%s' (use: %s)",
+
+	"ui.activity":       "Activity",
+	"ui.error":          "error: %s",
+	"ui.finishing":      "Waiting for %d running action(s) to finish...",
+	"ui.keys":           "↑/↓ select   u start   d stop   r restart   l logs   s refresh   q quit",
+	"ui.logs_title":     "Logs of %s (l or Esc closes)",
+	"ui.no_terminal":    "dcm ui needs an interactive terminal",
+	"ui.restarting":     "restarting",
+	"ui.running":        "%d/%d running",
+	"ui.starting":       "starting",
+	"ui.stopped":        "stopped",
+	"ui.stopping":       "stopping",
+	"ui.terminal_error": "error configuring the terminal: %w",
+
+	"uninstall.check_error":     "Error checking installation: %v",
+	"uninstall.checked_path":    "Checked path: %s",
+	"uninstall.done":            "dcm uninstalled!",
+	"uninstall.found":           "DCM found at: %s",
+	"uninstall.goodbye":         "Sorry dcm didn't work out for your project!",
+	"uninstall.not_found":       "dcm not found!",
+	"uninstall.not_installed":   "DCM is not installed",
+	"uninstall.remove_error":    "Error removing: %v",
+	"uninstall.removing":        "Removing %s",
+	"uninstall.removing_binary": "Removing %s...",
+	"uninstall.success":         "DCM uninstalled successfully!",
+	"uninstall.thanks":          "Thanks for using DCM!",
+	"uninstall.title":           "DCM - Uninstaller",
+
+	"up.group_ready":    "Group ready!",
+	"up.ready":          "%s is ready!",
+	"up.rollback_error": "Error rolling back %s: %v",
+	"up.rolled_back":    "failed to start '%s'; the services that were started have been stopped",
+	"up.rolling_back":   "Rolling back: stopping the services started in this run...",
+	"up.some_failed":    "some services failed to start",
+	"up.starting":       "Starting %s",
+	"up.starting_group": "Starting group '%s' (parallel=%v)...",
+	"up.stopped":        "failed to start '%s'; execution stopped",
+
+	"validate.group_error":           "Group '%s': %v",
+	"validate.invalid":               "%s is invalid: %d problem(s) found",
+	"validate.negative_max_parallel": "Group '%s': maxParallel cannot be negative",
+	"validate.ok":                    "Workspace is valid!",
+	"validate.path_not_found":        "Project '%s': path not found: %s",
+	"validate.project_error":         "Project '%s': %v",
+	"validate.task_error":            "Project '%s', task '%s': %v",
+	"validate.undefined_dependency":  "Project '%s': dependency '%s' is not defined",
+	"validate.undefined_project":     "Group '%s': project '%s' is not defined",
+	"validate.unknown_extends":       "Group '%s': extends unknown group '%s'",
+	"validate.validating":            "Validating %s...",
+
+	"workspace.file_not_found":       "file %s not found",
+	"workspace.group_not_found":      "group '%s' not found",
+	"workspace.interpolate_error":    "error interpolating %s: %w",
+	"workspace.invalid":              "invalid workspace",
+	"workspace.invalid_on_failure":   "invalid onFailure '%s' (use: %s, %s or %s)",
+	"workspace.invalid_timeout_for":  "invalid timeout for '%s': '%s'",
+	"workspace.line_prefix":          "line ",
+	"workspace.none_in_dir":          "no workspace found in %s (%s)",
+	"workspace.not_found":            "workspace not found",
+	"workspace.not_found_in_parents": "workspace.json not found in parent directories (also accepted: workspace.yaml, workspace.yml, workspace.toml)",
+	"workspace.parse_error":          "error parsing %s: %s",
+	"workspace.parse_error_wrap":     "error parsing %s: %w",
+	"workspace.path_not_found":       "workspace %s not found",
+	"workspace.position":             "line %d, column %d: %v",
+	"workspace.project_not_found":    "project '%s' not found",
+	"workspace.read_error":           "could not read %s: %w",
+	"workspace.target_not_found":     "group or project '%s' not found",
+	"workspace.unknown_timeout":      "unknown operation in timeouts: '%s' (use: %s)",
+	"workspace.unsupported_format":   "unsupported workspace format: %s",
+}
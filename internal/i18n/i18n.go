@@ -0,0 +1,126 @@
+// Package i18n guarda os catálogos de mensagens do dcm e escolhe o idioma a partir de --lang,
+// LC_ALL, LC_MESSAGES ou LANG.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+const DefaultLocale = "pt-BR"
+
+// Catalog associa cada chave ao texto no idioma. Os textos são formatos do fmt.
+type Catalog map[string]string
+
+var catalogs = map[string]Catalog{
+	"pt-BR": ptBR,
+	"en":    en,
+}
+
+// O idioma só muda quando o programa pede (SetLocale), para que bibliotecas e testes tenham
+// mensagens previsíveis independentemente do ambiente.
+var current = DefaultLocale
+
+// Locales devolve os idiomas disponíveis, em ordem alfabética.
+func Locales() []string {
+	locales := make([]string, 0, len(catalogs))
+	for locale := range catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Normalize converte nomes como "en_US.UTF-8", "pt_BR" ou "PT" para um idioma disponível.
+// Devolve "" se nenhum catálogo atende.
+func Normalize(name string) string {
+	name = strings.TrimSpace(name)
+	if i := strings.IndexAny(name, ".@"); i >= 0 {
+		name = name[:i]
+	}
+	name = strings.ReplaceAll(name, "_", "-")
+	if name == "" || name == "C" || name == "POSIX" {
+		return ""
+	}
+
+	for locale := range catalogs {
+		if strings.EqualFold(locale, name) {
+			return locale
+		}
+	}
+	// Sem catálogo para a região, vale o do idioma: "en-GB" usa "en", "pt-PT" usa "pt-BR"
+	language, _, _ := strings.Cut(name, "-")
+	for _, locale := range Locales() {
+		prefix, _, _ := strings.Cut(locale, "-")
+		if strings.EqualFold(prefix, language) {
+			return locale
+		}
+	}
+	return ""
+}
+
+// DetectLocale escolhe o idioma pelas variáveis de ambiente, na precedência do POSIX.
+func DetectLocale() string {
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := os.Getenv(env); value != "" {
+			if locale := Normalize(value); locale != "" {
+				return locale
+			}
+			// Uma variável mais prioritária definida com um idioma sem catálogo encerra a busca
+			return DefaultLocale
+		}
+	}
+	return DefaultLocale
+}
+
+// SetLocale troca o idioma das mensagens (flag --lang).
+func SetLocale(name string) error {
+	locale := Normalize(name)
+	if locale == "" {
+		return Errorf("i18n.unknown_locale", name, strings.Join(Locales(), ", "))
+	}
+	current = locale
+	return nil
+}
+
+func Locale() string {
+	return current
+}
+
+// T devolve a mensagem da chave no idioma atual, formatada com args. Chaves ausentes caem para o
+// catálogo padrão e, em último caso, para a própria chave.
+func T(key string, args ...any) string {
+	format, ok := catalogs[current][key]
+	if !ok {
+		if format, ok = catalogs[DefaultLocale][key]; !ok {
+			format = key
+		}
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// Errorf cria um erro com a mensagem da chave; aceita %w como fmt.Errorf.
+func Errorf(key string, args ...any) error {
+	return fmt.Errorf(T(key), args...)
+}
+
+// Message é um erro cuja mensagem é traduzida só quando exibida. Serve para erros sentinela,
+// criados antes de o idioma ser escolhido.
+type Message string
+
+func (m Message) Error() string { return T(string(m)) }
+
+// Keys devolve as chaves de um catálogo, em ordem alfabética.
+func Keys(locale string) []string {
+	keys := make([]string, 0, len(catalogs[locale]))
+	for key := range catalogs[locale] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
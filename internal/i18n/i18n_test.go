@@ -0,0 +1,196 @@
+package i18n
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestCatalogsHaveSameKeys(t *testing.T) {
+	reference := catalogs[DefaultLocale]
+	for locale, catalog := range catalogs {
+		for key := range reference {
+			if _, ok := catalog[key]; !ok {
+				t.Errorf("%s: falta a chave %q", locale, key)
+			}
+		}
+		for key := range catalog {
+			if _, ok := reference[key]; !ok {
+				t.Errorf("%s: chave %q não existe em %s", locale, key, DefaultLocale)
+			}
+		}
+	}
+}
+
+var verbPattern = regexp.MustCompile(`%[-+# 0-9.*]*[a-zA-Z%]`)
+
+// Os argumentos são os mesmos em todos os idiomas, então os verbos precisam aparecer na mesma ordem.
+func TestCatalogsHaveSameVerbs(t *testing.T) {
+	for locale, catalog := range catalogs {
+		for key, text := range catalog {
+			want := verbPattern.FindAllString(catalogs[DefaultLocale][key], -1)
+			got := verbPattern.FindAllString(text, -1)
+			if strings.Join(got, " ") != strings.Join(want, " ") {
+				t.Errorf("%s: %q usa %v, %s usa %v", locale, key, got, DefaultLocale, want)
+			}
+		}
+	}
+}
+
+// Quebras de linha e tabulações escapadas duas vezes no catálogo apareceriam como "\n" no terminal.
+func TestCatalogsHaveNoEscapedControls(t *testing.T) {
+	for locale, catalog := range catalogs {
+		for key, text := range catalog {
+			if strings.Contains(text, `\n`) || strings.Contains(text, `\t`) {
+				t.Errorf("%s: %q tem \\n ou \\t literal: %q", locale, key, text)
+			}
+		}
+	}
+}
+
+var (
+	// Chamadas com a chave literal: i18n.T("..."), i18n.Errorf("...") e i18n.Message("...")
+	callPattern = regexp.MustCompile(`(?:i18n\.|[^.\w])(?:T|Errorf|Message)\("([^"]+)"`)
+	// Campos que guardam chaves traduzidas na hora de exibir (commandDef, flagDef, action)
+	fieldPattern = regexp.MustCompile(`\b(?:Summary|Usage|Args|Value|Verb): "([a-z][a-z_]*\.[^"]+)"`)
+	// composeAction guarda só o prefixo das chaves
+	prefixPattern = regexp.MustCompile(`\bMessages: "([^"]+)"`)
+)
+
+// TestCodeKeysExist procura as chaves usadas no código e falha se alguma faltar em um catálogo.
+func TestCodeKeysExist(t *testing.T) {
+	used := map[string]string{}
+	err := filepath.WalkDir("../..", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && strings.HasPrefix(d.Name(), ".") && path != "../.." {
+			return filepath.SkipDir
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, pattern := range []*regexp.Regexp{callPattern, fieldPattern} {
+			for _, m := range pattern.FindAllStringSubmatch(string(data), -1) {
+				used[m[1]] = path
+			}
+		}
+		for _, m := range prefixPattern.FindAllStringSubmatch(string(data), -1) {
+			for _, suffix := range []string{".all", ".target", ".spec", ".done"} {
+				used[m[1]+suffix] = path
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(used) < 100 {
+		t.Fatalf("apenas %d chaves encontradas no código; o padrão de busca parou de funcionar?", len(used))
+	}
+
+	for key, path := range used {
+		for locale, catalog := range catalogs {
+			if _, ok := catalog[key]; !ok {
+				t.Errorf("%s: chave %q (usada em %s) não existe", locale, key, path)
+			}
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"pt_BR.UTF-8":     "pt-BR",
+		"pt-br":           "pt-BR",
+		"pt_PT":           "pt-BR",
+		"en":              "en",
+		"en_US.UTF-8":     "en",
+		"EN_gb@euro":      "en",
+		"C":               "",
+		"POSIX":           "",
+		"C.UTF-8":         "",
+		"fr_FR.UTF-8":     "",
+		"":                "",
+		"  en_US.UTF-8  ": "en",
+	}
+	for name, want := range tests {
+		if got := Normalize(name); got != want {
+			t.Errorf("Normalize(%q) = %q, esperado %q", name, got, want)
+		}
+	}
+}
+
+func TestDetectLocale(t *testing.T) {
+	tests := []struct {
+		lcAll, lcMessages, lang string
+		want                    string
+	}{
+		{"", "", "", DefaultLocale},
+		{"", "", "en_US.UTF-8", "en"},
+		{"", "en_US.UTF-8", "pt_BR.UTF-8", "en"},
+		{"pt_BR.UTF-8", "en_US.UTF-8", "en_US.UTF-8", "pt-BR"},
+		// A variável mais prioritária decide, mesmo sem catálogo para o idioma dela
+		{"fr_FR.UTF-8", "", "en_US.UTF-8", DefaultLocale},
+		{"", "", "C", DefaultLocale},
+	}
+	for _, tt := range tests {
+		t.Setenv("LC_ALL", tt.lcAll)
+		t.Setenv("LC_MESSAGES", tt.lcMessages)
+		t.Setenv("LANG", tt.lang)
+		if got := DetectLocale(); got != tt.want {
+			t.Errorf("LC_ALL=%q LC_MESSAGES=%q LANG=%q: %q, esperado %q", tt.lcAll, tt.lcMessages, tt.lang, got, tt.want)
+		}
+	}
+}
+
+func TestSetLocale(t *testing.T) {
+	t.Cleanup(func() { SetLocale(DefaultLocale) })
+
+	if err := SetLocale("en_US"); err != nil {
+		t.Fatal(err)
+	}
+	if got := T("validate.ok"); got != "Workspace is valid!" {
+		t.Errorf("T em inglês = %q", got)
+	}
+	if got := T("readiness.requires", "tcp", "address"); got != "readiness 'tcp' requires 'address'" {
+		t.Errorf("T com argumentos = %q", got)
+	}
+
+	err := SetLocale("klingon")
+	if err == nil || !strings.Contains(err.Error(), "unknown language 'klingon' (use: en, pt-BR)") {
+		t.Errorf("idioma desconhecido: %v", err)
+	}
+	if Locale() != "en" {
+		t.Errorf("um idioma inválido não deveria trocar o atual (%s)", Locale())
+	}
+}
+
+func TestMessageTranslatesOnDisplay(t *testing.T) {
+	t.Cleanup(func() { SetLocale(DefaultLocale) })
+
+	sentinel := Message("errors.partial_failure")
+	wrapped := Errorf("action.error_in", "api", sentinel)
+	if !errors.Is(wrapped, sentinel) {
+		t.Error("Errorf deveria preservar o %w")
+	}
+	if wrapped.Error() != "erro em api: falha parcial" {
+		t.Errorf("pt-BR: %q", wrapped.Error())
+	}
+
+	SetLocale("en")
+	if sentinel.Error() != "partial failure" {
+		t.Errorf("en: %q", sentinel.Error())
+	}
+	if got := T("nonexistent.key"); got != "nonexistent.key" {
+		t.Errorf("chave ausente deveria cair para a própria chave, veio %q", got)
+	}
+}
@@ -0,0 +1,351 @@
+package i18n
+
+// ptBR é o catálogo padrão: toda chave usada no código precisa estar aqui.
+var ptBR = Catalog{
+	"action.error_in":    "erro em %s: %w",
+	"action.some_failed": "alguns serviços falharam",
+
+	"args.color_mode":       "<auto|always|never>",
+	"args.exec":             "<projeto:serviço> -- <comando>",
+	"args.file":             "<arquivo>",
+	"args.file_or_dir":      "<arquivo|dir>",
+	"args.group":            "<grupo>",
+	"args.init_format":      "<json|yaml|toml>",
+	"args.language":         "<idioma>",
+	"args.n":                "<n>",
+	"args.optional_command": "[comando]",
+	"args.optional_target":  "[alvo]",
+	"args.output_format":    "<text|json|yaml>",
+	"args.progress_mode":    "<auto|text|live|json>",
+	"args.regex":            "<regex>",
+	"args.shell":            "<shell>",
+	"args.target":           "<alvo>",
+	"args.task":             "[projeto] [tarefa] [-- args]",
+	"args.time":             "<t>",
+
+	"build.all":    "Construindo todos os serviços...",
+	"build.done":   "Todos construídos!",
+	"build.spec":   "Construindo %s",
+	"build.target": "Construindo '%s' (parallel=%v)...",
+
+	"cli.interrupting":           "Interrompendo... (Ctrl+C de novo para forçar)",
+	"cli.invalid_jobs":           "valor inválido para --jobs: %s (use um número maior que zero)",
+	"cli.invalid_progress":       "valor inválido para --progress: '%s' (use: %s)",
+	"cli.invalid_value":          "valor inválido para --%s: %s",
+	"cli.last_with_target":       "--last não aceita um alvo",
+	"cli.missing_args":           "argumentos insuficientes: dcm %s %s",
+	"cli.missing_value":          "flag %s exige um valor %s",
+	"cli.too_many_args":          "argumentos demais para %s: %s",
+	"cli.unexpected_passthrough": "o comando '%s' não aceita argumentos depois de \"--\"",
+	"cli.unknown_command":        "comando desconhecido: %s (use 'dcm help')",
+	"cli.unknown_command_flag":   "flag desconhecida para %s: %s (use 'dcm help %s')",
+	"cli.unknown_flag":           "flag desconhecida: %s",
+	"cli.verbose_quiet":          "--verbose e --quiet não podem ser usados juntos",
+
+	"cmd.build.summary":      "Constrói as imagens do alvo",
+	"cmd.completion.summary": "Gera o script de autocompletar (bash, zsh, fish, powershell)",
+	"cmd.config.summary":     "Mostra o workspace resolvido",
+	"cmd.down.summary":       "Para o alvo (ou todos os serviços)",
+	"cmd.exec.summary":       "Executa um comando em um serviço que está rodando",
+	"cmd.help.summary":       "Mostra a ajuda geral ou de um comando",
+	"cmd.history.summary":    "Execuções anteriores de up, down, restart, pull e build",
+	"cmd.init.summary":       "Cria configuração inicial",
+	"cmd.inspect.summary":    "Detalha composição de um grupo",
+	"cmd.list.summary":       "Lista projetos e grupos",
+	"cmd.logs.summary":       "Logs dos projetos, com prefixo por projeto",
+	"cmd.pull.summary":       "Atualiza as imagens do alvo",
+	"cmd.restart.summary":    "Reinicia o alvo (ou todos)",
+	"cmd.run.summary":        "Executa um comando em um container novo do serviço",
+	"cmd.status.summary":     "Status dos serviços",
+	"cmd.task.summary":       "Lista ou executa as tarefas dos projetos",
+	"cmd.ui.summary":         "Painel interativo com o estado dos serviços",
+	"cmd.up.summary":         "Inicia grupo, projeto ou projeto:serviço",
+	"cmd.validate.summary":   "Valida o arquivo do workspace",
+	"cmd.version.summary":    "Mostra versão",
+
+	"common.error":    "Erro: %v",
+	"common.error_in": "Erro em %s: %v",
+
+	"completion.unsupported_shell": "shell não suportado: %s (use: %s)",
+
+	"compose.error":       "erro: %v",
+	"compose.exit_code":   "erro: '%s' terminou com código %d",
+	"compose.full_log":    "Log completo: %s",
+	"compose.interrupted": "erro: '%s' interrompido",
+	"compose.output_tail": "Saída de %s (últimas %d linhas):",
+	"compose.timed_out":   "erro: '%s' excedeu o tempo limite",
+
+	"dotenv.invalid_line": "linha %d inválida: esperado CHAVE=valor",
+	"dotenv.read_error":   "erro ao ler %s: %w",
+
+	"down.all_stopped":           "Todos parados!",
+	"down.alphabetical_order":    "%v (usando ordem alfabética)",
+	"down.group_services_failed": "%d serviço(s) do grupo '%s' falharam ao parar",
+	"down.group_stopped":         "Grupo '%s' parado!",
+	"down.projects_failed":       "%d projeto(s) falharam ao parar",
+	"down.removing_volumes":      " e removendo volumes",
+	"down.stopping":              "Parando %s",
+	"down.stopping_all":          "Parando todos os serviços%s...",
+	"down.stopping_group":        "Parando grupo '%s'%s...",
+
+	"engine.compose_file_not_found": "arquivo compose não encontrado: %s",
+	"engine.empty_profile":          "profile vazio",
+	"engine.env_file_not_found":     "envFile não encontrado: %s",
+	"engine.invalid_project_name":   "projectName inválido '%s' (use letras minúsculas, números, '-' e '_')",
+	"engine.none":                   "nenhum engine compose encontrado (docker compose, docker-compose, podman-compose ou nerdctl compose)",
+	"engine.unknown":                "engine '%s' desconhecido (use: %s)",
+
+	"errors.compose_failed":  "falha no compose",
+	"errors.interrupted":     "operação interrompida",
+	"errors.partial_failure": "falha parcial",
+
+	"exec.error":            "erro ao executar o comando: %v",
+	"exec.exit_code":        "o comando terminou com código %d",
+	"exec.missing_command":  "informe o comando depois de \"--\"",
+	"exec.service_choices":  "o projeto '%s' tem mais de um serviço; informe \"projeto:serviço\" (%s)",
+	"exec.service_required": "o projeto '%s' não tem serviços; informe \"projeto:serviço\"",
+
+	"flag.build":     "Reconstrói as imagens antes de iniciar",
+	"flag.color":     "Cores na saída: auto (apenas em terminais; respeita NO_COLOR e FORCE_COLOR), always ou never",
+	"flag.dry-run":   "Mostra os comandos que seriam executados, sem executá-los",
+	"flag.fail-fast": "Interrompe na primeira falha (equivale a onFailure: stop)",
+	"flag.file":      "Mescla outro arquivo sobre o workspace (repetível)",
+	"flag.follow":    "Acompanha os logs até Ctrl+C",
+	"flag.format":    "Formato do arquivo criado (padrão: json)",
+	"flag.grep":      "Mostra apenas as linhas que casam com a regex",
+	"flag.jobs":      "Máximo de serviços executados ao mesmo tempo (sobrepõe o maxParallel dos grupos)",
+	"flag.lang":      "Idioma das mensagens (pt-BR, en); o padrão vem de LC_ALL, LC_MESSAGES ou LANG",
+	"flag.last":      "Para exatamente o que o último 'up' iniciou",
+	"flag.limit":     "Quantas execuções mostrar (padrão: 20, 0 = todas)",
+	"flag.no-cache":  "Não usa o cache ao construir as imagens",
+	"flag.output":    "Formato de saída de status, list e inspect",
+	"flag.plain":     "Saída sem emojis, apenas texto",
+	"flag.progress":  "Progresso de up, down, restart, pull e build: auto (ao vivo em terminais), text, live ou json (uma linha JSON por evento; o resto vai para o stderr)",
+	"flag.pull":      "Sempre tenta baixar versões novas das imagens base",
+	"flag.quiet":     "Exibe apenas erros e a saída de dados",
+	"flag.save-logs": "Grava a saída completa de cada comando do compose em .dcm/logs",
+	"flag.since":     "Logs desde um horário ou duração (ex: 10m)",
+	"flag.sources":   "Mostra o arquivo de origem de cada valor",
+	"flag.tail":      "Número de linhas por container",
+	"flag.verbose":   "Mostra cada comando executado e toda a saída do compose",
+	"flag.volumes":   "Remove também os volumes",
+	"flag.workspace": "Usa este workspace em vez de procurar nos diretórios pais",
+
+	"graph.cycle":         "ciclo de dependências detectado: %s",
+	"graph.dependency_of": "dependência de '%s': %w",
+
+	"group.inheritance_cycle": "ciclo de herança detectado no grupo '%s'",
+
+	"help.command_hint":        "Use 'dcm help <comando>' para ver as opções de um comando.",
+	"help.commands":            "Comandos:",
+	"help.global_options":      "Opções globais:",
+	"help.options":             "Opções:",
+	"help.options_placeholder": "[opções]",
+	"help.usage":               "Uso: %s",
+	"help.usage_line":          "<comando> [opções]",
+	"help.usage_title":         "Uso:",
+	"help.version":             "Versão: %s",
+
+	"history.all_targets":        "(todos)",
+	"history.empty":              "Nenhuma execução registrada neste workspace.",
+	"history.header":             "QUANDO\tCOMANDO\tALVO\tRESULTADO\tDURAÇÃO\tSERVIÇOS",
+	"history.last_stopped":       "Serviços do último 'up' parados!",
+	"history.no_up":              "nenhum 'up' registrado neste workspace (veja 'dcm history')",
+	"history.nothing_to_undo":    "o que os 'up' registrados iniciaram já foi parado (veja 'dcm history')",
+	"history.result_failed":      "falhou",
+	"history.result_interrupted": "interrompido",
+	"history.result_ok":          "ok",
+	"history.result_partial":     "parcial",
+	"history.stop_failed":        "%d serviço(s) falharam ao parar",
+	"history.stopping_last":      "Parando o que o 'up %s' de %s iniciou...",
+	"history.write_error":        "Não foi possível gravar o histórico: %v",
+
+	"i18n.unknown_locale": "idioma desconhecido '%s' (use: %s)",
+
+	"init.create_error":   "erro ao criar %s: %w",
+	"init.created":        "%s criado com sucesso!",
+	"init.exists":         "%s já existe",
+	"init.invalid_format": "formato inválido '%s' (use: json, yaml, toml)",
+
+	"inspect.all_services": "todos",
+	"inspect.config":       "Configuração: %s, onFailure=%s",
+	"inspect.depends_on":   "Depende de: %s",
+	"inspect.path":         "Caminho: %s",
+	"inspect.service":      "Serviço: %s",
+	"inspect.services":     "Serviços na ordem de execução:",
+	"inspect.title":        "Inspeção do grupo: %s",
+	"inspect.waves":        "Etapas de inicialização:",
+
+	"install.admin_required":   "ERRO: Necessário privilégios de Administrador",
+	"install.binary_not_found": "binário '%s' não encontrado no diretório atual",
+	"install.chmod":            "Ajustando permissões...",
+	"install.chmod_error":      "erro ao ajustar permissões: %w",
+	"install.copy_error":       "erro ao copiar arquivo: %w",
+	"install.copying":          "Copiando binário para %s",
+	"install.create_error":     "erro ao criar destino (pode precisar executar como Admin): %w",
+	"install.detected":         "Detectado: %s",
+	"install.example":          "Exemplo:",
+	"install.finish_error":     "erro ao finalizar cópia: %w",
+	"install.found":            "Encontrado em: %s",
+	"install.installing":       "Instalando DCM globalmente...",
+	"install.manual_windows":   "Mova dcm.exe para C:\\Windows\\System32\\ (execute como Admin)",
+	"install.not_in_path":      "DCM não encontrado no PATH",
+	"install.open_error":       "erro ao abrir arquivo: %w",
+	"install.pipe_error":       "erro ao criar pipe: %w",
+	"install.run_as_admin":     "Clique direito no %s > 'Executar como administrador'",
+	"install.source_found":     "Encontrado: %s",
+	"install.success":          "DCM instalado com sucesso!",
+	"install.sudo_error":       "erro ao executar sudo: %w",
+	"install.title":            "DCM - Instalador Global",
+	"install.try_manually":     "Tente executar manualmente:",
+	"install.trying_sudo":      "Permissão negada, tentando com sudo...",
+	"install.unsupported_os":   "SO não suportado: %s",
+	"install.usage":            "Uso: Coloque dcm no diretório atual e execute este instalador.",
+	"install.usage_hint":       "Você pode usar 'dcm' em qualquer terminal/pasta.",
+	"install.validating":       "Validando instalação...",
+	"install.version_error":    "erro ao executar '%s version': %w\nSaída: %s",
+
+	"interpolate.invalid_variable": "variável inválida '${%s}'",
+	"interpolate.not_set":          "não definida",
+	"interpolate.required":         "variável obrigatória %s: %s",
+	"interpolate.unclosed":         "'${' sem '}' correspondente em \"%s\"",
+
+	"list.groups":   "Grupos:",
+	"list.projects": "Projetos:",
+
+	"live.waiting": "aguardando readiness (%s)",
+
+	"logs.dir_error":      "Não foi possível criar %s: %v",
+	"logs.failed":         "falha ao obter logs de: %s",
+	"logs.file_error":     "Não foi possível criar o log de %s: %v",
+	"logs.invalid_filter": "filtro inválido '%s': %w",
+	"logs.stream_error":   "erro: %w",
+
+	"messages.press_enter": "Pressione ENTER para sair...",
+
+	"output.invalid_format": "formato de saída inválido '%s' (use: text, json, yaml)",
+
+	"plan.not_started": "não iniciado",
+	"plan.skipped":     "%s ignorado: dependência '%s' falhou",
+	"plan.stopped":     "Execução interrompida; não iniciados: %s",
+	"plan.wave":        "Etapa %d/%d: %s",
+
+	"pull.all":    "Atualizando imagens de todos os serviços...",
+	"pull.done":   "Todos atualizados!",
+	"pull.spec":   "Atualizando imagens de %s",
+	"pull.target": "Atualizando imagens de '%s' (parallel=%v)...",
+
+	"readiness.container_state":  "%s está %s",
+	"readiness.dry_run":          "aguardar %s (%s, timeout %s)",
+	"readiness.http_status":      "%s respondeu %d",
+	"readiness.interrupted":      "espera por %s interrompida: %w",
+	"readiness.invalid_interval": "interval inválido '%s'",
+	"readiness.invalid_ps":       "saída inválida do compose ps: %w",
+	"readiness.invalid_timeout":  "timeout inválido '%s'",
+	"readiness.no_containers":    "nenhum container em execução",
+	"readiness.ps_error":         "erro ao consultar containers: %w",
+	"readiness.ready":            "%s pronto em %s",
+	"readiness.requires":         "readiness '%s' exige '%s'",
+	"readiness.timeout":          "%s não ficou pronto em %s: %v",
+	"readiness.unknown_type":     "tipo de readiness desconhecido '%s' (use: healthy, tcp, http, command)",
+	"readiness.waiting":          "Aguardando %s ficar pronto (%s)...",
+
+	"restart.all":    "Reiniciando todos os serviços...",
+	"restart.done":   "Todos reiniciados!",
+	"restart.spec":   "Reiniciando %s",
+	"restart.target": "Reiniciando '%s' (parallel=%v)...",
+
+	"services.read_error": "erro ao ler %s: %w",
+
+	"state.invalid": "%s inválido: %w",
+	"state.locked":  "%s está bloqueado por outro dcm (remova o arquivo se nenhum estiver rodando)",
+
+	"status.all":               "Status de todos os serviços:",
+	"status.group":             "Status de '%s':",
+	"status.project_not_found": "Projeto '%s' não encontrado",
+
+	"summary.failed":      "falhou",
+	"summary.header":      "SERVIÇO\tDURAÇÃO\tRESULTADO",
+	"summary.interrupted": "interrompido",
+	"summary.ok":          "ok",
+	"summary.skipped":     "ignorado",
+	"summary.title":       "Resumo: %s",
+	"summary.total":       "Total: %s",
+
+	"task.none":            "Nenhuma tarefa definida no workspace",
+	"task.none_in_project": "o projeto '%s' não define tarefas",
+	"task.not_found":       "tarefa '%s' não encontrada no projeto '%s' (disponíveis: %s)",
+	"task.requires":        "tarefa precisa do campo \"%s\"",
+	"task.title":           "Tarefas:",
+
+	"terminal.invalid_color": "valor inválido para --color: '%s' (use: %s)",
+
+	"ui.activity":       "Atividade",
+	"ui.error":          "erro: %s",
+	"ui.finishing":      "Aguardando %d ação(ões) em andamento encerrar...",
+	"ui.keys":           "↑/↓ selecionar   u iniciar   d parar   r reiniciar   l logs   s atualizar   q sair",
+	"ui.logs_title":     "Logs de %s (l ou Esc fecha)",
+	"ui.no_terminal":    "dcm ui precisa de um terminal interativo",
+	"ui.restarting":     "reiniciando",
+	"ui.running":        "%d/%d em execução",
+	"ui.starting":       "iniciando",
+	"ui.stopped":        "parado",
+	"ui.stopping":       "parando",
+	"ui.terminal_error": "erro ao configurar o terminal: %w",
+
+	"uninstall.check_error":     "Erro ao verificar instalação: %v",
+	"uninstall.checked_path":    "Caminho verificado: %s",
+	"uninstall.done":            "dcm desinstalado!",
+	"uninstall.found":           "DCM encontrado em: %s",
+	"uninstall.goodbye":         "Uma pena que o dcm não atendeu o seu projeto!",
+	"uninstall.not_found":       "dcm não encontrado!",
+	"uninstall.not_installed":   "DCM não está instalado",
+	"uninstall.remove_error":    "Erro ao remover: %v",
+	"uninstall.removing":        "Removendo %s",
+	"uninstall.removing_binary": "Removendo %s...",
+	"uninstall.success":         "DCM desinstalado com sucesso!",
+	"uninstall.thanks":          "Obrigado por usar o DCM!",
+	"uninstall.title":           "DCM - Desinstalador",
+
+	"up.group_ready":    "Grupo pronto!",
+	"up.ready":          "%s pronto!",
+	"up.rollback_error": "Erro ao reverter %s: %v",
+	"up.rolled_back":    "falha ao iniciar '%s'; os serviços iniciados foram parados",
+	"up.rolling_back":   "Revertendo: parando os serviços iniciados nesta execução...",
+	"up.some_failed":    "alguns serviços falharam ao iniciar",
+	"up.starting":       "Iniciando %s",
+	"up.starting_group": "Iniciando grupo '%s' (parallel=%v)...",
+	"up.stopped":        "falha ao iniciar '%s'; execução interrompida",
+
+	"validate.group_error":           "Grupo '%s': %v",
+	"validate.invalid":               "%s inválido: %d problema(s) encontrado(s)",
+	"validate.negative_max_parallel": "Grupo '%s': maxParallel não pode ser negativo",
+	"validate.ok":                    "Workspace válido!",
+	"validate.path_not_found":        "Projeto '%s': caminho não encontrado: %s",
+	"validate.project_error":         "Projeto '%s': %v",
+	"validate.task_error":            "Projeto '%s', tarefa '%s': %v",
+	"validate.undefined_dependency":  "Projeto '%s': dependência '%s' não definida",
+	"validate.undefined_project":     "Grupo '%s': projeto '%s' não definido",
+	"validate.unknown_extends":       "Grupo '%s': estende grupo inexistente '%s'",
+	"validate.validating":            "Validando %s...",
+
+	"workspace.file_not_found":       "arquivo %s não encontrado",
+	"workspace.group_not_found":      "grupo '%s' não encontrado",
+	"workspace.interpolate_error":    "erro ao interpolar %s: %w",
+	"workspace.invalid":              "workspace inválido",
+	"workspace.invalid_on_failure":   "onFailure inválido '%s' (use: %s, %s ou %s)",
+	"workspace.invalid_timeout_for":  "timeout inválido para '%s': '%s'",
+	"workspace.line_prefix":          "linha ",
+	"workspace.none_in_dir":          "nenhum workspace encontrado em %s (%s)",
+	"workspace.not_found":            "workspace não encontrado",
+	"workspace.not_found_in_parents": "workspace.json não encontrado nos diretórios pais (também aceito: workspace.yaml, workspace.yml, workspace.toml)",
+	"workspace.parse_error":          "erro ao parsear %s: %s",
+	"workspace.parse_error_wrap":     "erro ao parsear %s: %w",
+	"workspace.path_not_found":       "workspace %s não encontrado",
+	"workspace.position":             "linha %d, coluna %d: %v",
+	"workspace.project_not_found":    "projeto '%s' não encontrado",
+	"workspace.read_error":           "não foi possível ler %s: %w",
+	"workspace.target_not_found":     "grupo ou projeto '%s' não encontrado",
+	"workspace.unknown_timeout":      "operação desconhecida em timeouts: '%s' (use: %s)",
+	"workspace.unsupported_format":   "formato de workspace não suportado: %s",
+}
@@ -0,0 +1,200 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Disneyjr/dcm/internal/i18n"
+)
+
+const (
+	Dir      = ".dcm" // Pasta do dcm dentro do BaseDir do workspace
+	FileName = "state.json"
+	lockName = "state.lock"
+
+	// MaxRuns limita o histórico; as execuções mais antigas são descartadas.
+	MaxRuns = 100
+)
+
+// Resultados possíveis de uma execução.
+const (
+	ResultOK          = "ok"
+	ResultPartial     = "partial"
+	ResultFailed      = "failed"
+	ResultInterrupted = "interrupted"
+)
+
+var (
+	lockTimeout  = 5 * time.Second
+	lockRetry    = 50 * time.Millisecond
+	staleLockAge = 30 * time.Second // Um lock mais velho que isso é de um dcm que morreu no meio da escrita
+)
+
+// Run é uma execução registrada de um comando que altera os serviços (up, down, restart, ...).
+type Run struct {
+	Command  string    `json:"command" yaml:"command"`
+	Target   string    `json:"target,omitempty" yaml:"target,omitempty"`
+	Args     []string  `json:"args,omitempty" yaml:"args,omitempty"`
+	Specs    []string  `json:"specs,omitempty" yaml:"specs,omitempty"` // Specs em que a operação deu certo, na ordem de execução
+	Started  time.Time `json:"started" yaml:"started"`
+	Finished time.Time `json:"finished" yaml:"finished"`
+	Result   string    `json:"result" yaml:"result"`
+	Error    string    `json:"error,omitempty" yaml:"error,omitempty"`
+	Undoes   time.Time `json:"undoes,omitzero" yaml:"undoes,omitempty"` // No "down --last", o início do "up" desfeito
+}
+
+type State struct {
+	Runs []Run `json:"runs" yaml:"runs"` // Da mais antiga para a mais recente
+}
+
+// LastRun devolve a execução mais recente do comando que ainda tenha specs pendentes, junto com
+// eles, ou nil. Um spec deixa de estar pendente quando um "down" posterior (manual ou --last) o
+// parou, então um "up" já desfeito é ignorado.
+func (s *State) LastRun(command string) (*Run, []string) {
+	for i := len(s.Runs) - 1; i >= 0; i-- {
+		if s.Runs[i].Command != command {
+			continue
+		}
+		if pending := s.pending(i); len(pending) > 0 {
+			return &s.Runs[i], pending
+		}
+	}
+	return nil, nil
+}
+
+// pending devolve os specs da execução i que nenhum "down" registrado depois dela parou.
+func (s *State) pending(i int) []string {
+	var pending []string
+	for _, spec := range s.Runs[i].Specs {
+		stopped := false
+		for _, later := range s.Runs[i+1:] {
+			if later.Command == "down" && stops(later.Specs, spec) {
+				stopped = true
+				break
+			}
+		}
+		if !stopped {
+			pending = append(pending, spec)
+		}
+	}
+	return pending
+}
+
+// stops indica se parar specs para também spec: o mesmo spec ou o projeto inteiro de um
+// "projeto:serviço".
+func stops(specs []string, spec string) bool {
+	project, _, _ := strings.Cut(spec, ":")
+	for _, stopped := range specs {
+		if stopped == spec || stopped == project {
+			return true
+		}
+	}
+	return false
+}
+
+// Add acrescenta uma execução, descartando as mais antigas acima de MaxRuns.
+func (s *State) Add(run Run) {
+	s.Runs = append(s.Runs, run)
+	if len(s.Runs) > MaxRuns {
+		s.Runs = s.Runs[len(s.Runs)-MaxRuns:]
+	}
+}
+
+// Path devolve o caminho do arquivo de estado de um workspace.
+func Path(baseDir string) string {
+	return filepath.Join(baseDir, Dir, FileName)
+}
+
+// Load lê o estado do workspace. Um arquivo inexistente equivale a um estado vazio.
+func Load(baseDir string) (*State, error) {
+	s := &State{}
+	data, err := os.ReadFile(Path(baseDir))
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, i18n.Errorf("state.invalid", Path(baseDir), err)
+	}
+	return s, nil
+}
+
+// Update lê, altera com fn e grava o estado com o lock do workspace, para que dcm rodando ao
+// mesmo tempo não percam as execuções uns dos outros.
+func Update(baseDir string, fn func(s *State) error) error {
+	dir := filepath.Join(baseDir, Dir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	unlock, err := lock(filepath.Join(dir, lockName))
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	s, err := Load(baseDir)
+	if err != nil {
+		return err
+	}
+	if err := fn(s); err != nil {
+		return err
+	}
+	return write(Path(baseDir), s)
+}
+
+// write grava em um arquivo temporário e renomeia, para que o arquivo nunca fique pela metade.
+func write(path string, s *State) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), FileName+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// lock cria o arquivo de lock de forma exclusiva, esperando até lockTimeout se outro dcm o tiver.
+func lock(path string) (func(), error) {
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			fmt.Fprintf(f, "%d\n", os.Getpid())
+			f.Close()
+			return func() { os.Remove(path) }, nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, err
+		}
+
+		if info, statErr := os.Stat(path); statErr == nil && time.Since(info.ModTime()) > staleLockAge {
+			os.Remove(path)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, i18n.Errorf("state.locked", path)
+		}
+		time.Sleep(lockRetry)
+	}
+}
@@ -0,0 +1,114 @@
+package state
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestUpdateAndLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	s, err := Load(dir)
+	if err != nil || len(s.Runs) != 0 {
+		t.Fatalf("expected empty state, got %+v (%v)", s, err)
+	}
+
+	runs := []Run{
+		{Command: "up", Target: "dev", Specs: []string{"db", "api"}, Result: ResultOK},
+		{Command: "up", Target: "tools", Result: ResultFailed},
+		{Command: "down", Target: "dev", Specs: []string{"api"}, Result: ResultOK},
+	}
+	for _, run := range runs {
+		if err := Update(dir, func(s *State) error { s.Add(run); return nil }); err != nil {
+			t.Fatalf("update failed: %v", err)
+		}
+	}
+
+	s, err = Load(dir)
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if len(s.Runs) != 3 {
+		t.Fatalf("expected 3 runs, got %d", len(s.Runs))
+	}
+	// O "up" que falhou não iniciou nada, então o último com specs é o do grupo dev, sem o api já parado
+	if last, pending := s.LastRun("up"); last == nil || last.Target != "dev" || !reflect.DeepEqual(pending, []string{"db"}) {
+		t.Errorf("unexpected last up: %+v (pending %v)", last, pending)
+	}
+	if _, err := os.Stat(filepath.Join(dir, Dir, lockName)); !os.IsNotExist(err) {
+		t.Error("expected lock to be released")
+	}
+}
+
+func TestLastRunSkipsUndone(t *testing.T) {
+	s := &State{Runs: []Run{
+		{Command: "up", Target: "dev", Specs: []string{"db", "api:web"}},
+		{Command: "up", Target: "tools", Specs: []string{"tools"}},
+		{Command: "down", Target: "tools", Specs: []string{"tools"}, Undoes: time.Now()},
+	}}
+
+	if last, pending := s.LastRun("up"); last == nil || last.Target != "dev" || len(pending) != 2 {
+		t.Errorf("expected dev after tools was undone, got %+v (pending %v)", last, pending)
+	}
+
+	// Um "down" manual do projeto inteiro também para o serviço "api:web"
+	s.Add(Run{Command: "down", Target: "dev", Specs: []string{"api", "db"}})
+	if last, _ := s.LastRun("up"); last != nil {
+		t.Errorf("expected nothing left to undo, got %+v", last)
+	}
+}
+
+func TestUpdateConcurrent(t *testing.T) {
+	dir := t.TempDir()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			err := Update(dir, func(s *State) error {
+				s.Add(Run{Command: "up", Target: fmt.Sprintf("g%d", i)})
+				return nil
+			})
+			if err != nil {
+				t.Errorf("update %d failed: %v", i, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	s, err := Load(dir)
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if len(s.Runs) != 20 {
+		t.Errorf("expected every concurrent update to be kept, got %d runs", len(s.Runs))
+	}
+}
+
+func TestMaxRunsAndStaleLock(t *testing.T) {
+	s := &State{}
+	for i := 0; i < MaxRuns+5; i++ {
+		s.Add(Run{Command: "up", Target: fmt.Sprint(i)})
+	}
+	if len(s.Runs) != MaxRuns || s.Runs[0].Target != "5" {
+		t.Errorf("expected oldest runs to be dropped, got %d runs starting at %s", len(s.Runs), s.Runs[0].Target)
+	}
+
+	// Um lock esquecido por um dcm que morreu não bloqueia para sempre
+	dir := t.TempDir()
+	lockPath := filepath.Join(dir, Dir, lockName)
+	os.MkdirAll(filepath.Dir(lockPath), 0755)
+	os.WriteFile(lockPath, []byte("123\n"), 0644)
+	old := time.Now().Add(-2 * staleLockAge)
+	os.Chtimes(lockPath, old, old)
+
+	if err := Update(dir, func(s *State) error { return nil }); err != nil {
+		t.Errorf("expected stale lock to be removed, got %v", err)
+	}
+}
@@ -0,0 +1,624 @@
+// Package ui implementa o painel interativo do "dcm ui" sobre o Manager de pkg/dcm.
+package ui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Disneyjr/dcm/internal/commands"
+	"github.com/Disneyjr/dcm/internal/i18n"
+	"github.com/Disneyjr/dcm/internal/workspace"
+	"github.com/Disneyjr/dcm/pkg/dcm"
+	"github.com/Disneyjr/dcm/utils"
+	"golang.org/x/term"
+)
+
+const (
+	refreshInterval = 2 * time.Second
+	maxPaneLines    = 1000
+	logTail         = "50"
+)
+
+// shutdownTimeout é quanto o painel espera, ao sair, pelas ações interrompidas: o mesmo prazo que
+// os comandos do compose têm para encerrar antes de serem mortos.
+var shutdownTimeout = commands.DefaultGracePeriod
+
+type rowKind int
+
+const (
+	rowGroup rowKind = iota
+	rowProject
+	rowService
+)
+
+// row é uma linha selecionável do painel. Target é o alvo repassado aos comandos:
+// nome do grupo, do projeto ou spec "projeto:serviço".
+type row struct {
+	Kind    rowKind
+	Target  string
+	Label   string
+	Project string
+	Service string
+}
+
+// buildRows lista os grupos e, depois, cada projeto seguido dos seus serviços.
+func buildRows(m *dcm.Manager) []row {
+	var rows []row
+	for _, name := range m.Groups() {
+		rows = append(rows, row{Kind: rowGroup, Target: name, Label: name})
+	}
+	for _, target := range m.Targets() {
+		if _, isGroup := m.Workspace().Groups[target]; isGroup {
+			continue
+		}
+		projectName, service, isSpec := strings.Cut(target, ":")
+		if isSpec {
+			rows = append(rows, row{Kind: rowService, Target: target, Label: service, Project: projectName, Service: service})
+		} else {
+			rows = append(rows, row{Kind: rowProject, Target: target, Label: target, Project: projectName})
+		}
+	}
+	return rows
+}
+
+// dashboard guarda o estado do painel. Tudo é protegido por mu, pois as ações, o refresh do
+// status e os logs rodam em goroutines próprias.
+type dashboard struct {
+	ctx     context.Context // Cancelado ao sair do painel, interrompendo as ações em andamento
+	ws      *workspace.Workspace
+	manager *dcm.Manager // Escreve a saída dos comandos no painel de atividade
+
+	mu         sync.Mutex
+	rows       []row
+	selected   int
+	status     map[string]dcm.ProjectStatus // Por projeto
+	errors     map[string]string            // Último erro de cada alvo
+	busy       map[string]string            // Ação em andamento em cada alvo
+	activity   []string                     // Saída dos comandos
+	logs       []string
+	logsTarget string
+	stopLogs   context.CancelFunc
+	refreshing bool
+
+	actions sync.WaitGroup // Ações em andamento; o painel espera por elas ao sair
+	redraw  chan struct{}
+}
+
+func newDashboard(ctx context.Context, m *dcm.Manager) *dashboard {
+	d := &dashboard{
+		ctx:    ctx,
+		ws:     m.Workspace(),
+		status: make(map[string]dcm.ProjectStatus),
+		errors: make(map[string]string),
+		busy:   make(map[string]string),
+		redraw: make(chan struct{}, 1),
+	}
+	// O progresso vira linhas da área de atividade, mesmo que o CLI tenha escolhido outro reporter
+	d.manager = m.With(dcm.WithOutput(&activityWriter{d: d}), dcm.WithReporter(nil))
+	d.rows = buildRows(d.manager)
+	return d
+}
+
+func (d *dashboard) requestRedraw() {
+	select {
+	case d.redraw <- struct{}{}:
+	default:
+	}
+}
+
+// Run abre o painel no terminal atual até o usuário sair com "q" ou Ctrl-C, ou até ctx terminar.
+// As ações usam as opções de m, mas escrevem no painel de atividade.
+func Run(ctx context.Context, m *dcm.Manager) error {
+	stdinFd := int(os.Stdin.Fd())
+	if !term.IsTerminal(stdinFd) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return i18n.Errorf("ui.no_terminal")
+	}
+
+	state, err := term.MakeRaw(stdinFd)
+	if err != nil {
+		return i18n.Errorf("ui.terminal_error", err)
+	}
+	defer term.Restore(stdinFd, state)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	d := newDashboard(ctx, m)
+	tty := os.Stdout
+
+	fmt.Fprint(tty, "\x1b[?1049h\x1b[?25l")
+	defer fmt.Fprint(tty, "\x1b[?25h\x1b[?1049l")
+
+	keys := make(chan key)
+	go readKeys(os.Stdin, keys)
+
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+
+	go d.refreshStatus()
+	d.render(tty)
+
+	for {
+		select {
+		case k, ok := <-keys:
+			if !ok || !d.handleKey(k) {
+				d.shutdown(cancel, tty)
+				return nil
+			}
+			d.render(tty)
+		case <-ticker.C:
+			go d.refreshStatus()
+			// O tamanho do terminal pode ter mudado
+			d.render(tty)
+		case <-d.redraw:
+			d.render(tty)
+		case <-ctx.Done():
+			d.shutdown(cancel, tty)
+			return nil
+		}
+	}
+}
+
+// shutdown interrompe as ações em andamento e espera que terminem (com o rollback, se houver), até
+// shutdownTimeout. Sair antes deixaria processos do compose órfãos no meio de um up ou down.
+func (d *dashboard) shutdown(cancel context.CancelFunc, out io.Writer) {
+	d.closeLogs()
+	cancel()
+
+	d.mu.Lock()
+	pending := len(d.busy)
+	if pending > 0 {
+		d.activity = appendLine(d.activity, i18n.T("ui.finishing", pending))
+	}
+	d.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		d.actions.Wait()
+		close(done)
+	}()
+
+	timeout := time.NewTimer(shutdownTimeout)
+	defer timeout.Stop()
+	for {
+		d.render(out)
+		select {
+		case <-done:
+			return
+		case <-timeout.C:
+			return
+		case <-d.redraw:
+		}
+	}
+}
+
+// activityWriter recebe a saída das ações e acumula as linhas não vazias no painel de atividade.
+// O Manager serializa as escritas, então partial não precisa de trava própria.
+type activityWriter struct {
+	d       *dashboard
+	partial string
+}
+
+func (w *activityWriter) Write(p []byte) (int, error) {
+	lines := strings.Split(w.partial+string(p), "\n")
+	w.partial = lines[len(lines)-1]
+
+	w.d.mu.Lock()
+	for _, line := range lines[:len(lines)-1] {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) != "" {
+			w.d.activity = appendLine(w.d.activity, line)
+		}
+	}
+	w.d.mu.Unlock()
+	w.d.requestRedraw()
+	return len(p), nil
+}
+
+func appendLine(lines []string, line string) []string {
+	lines = append(lines, line)
+	if len(lines) > maxPaneLines {
+		lines = lines[len(lines)-maxPaneLines:]
+	}
+	return lines
+}
+
+func (d *dashboard) refreshStatus() {
+	d.mu.Lock()
+	if d.refreshing {
+		d.mu.Unlock()
+		return
+	}
+	d.refreshing = true
+	d.mu.Unlock()
+
+	result, err := d.manager.Status(d.ctx, "")
+
+	d.mu.Lock()
+	d.refreshing = false
+	if err == nil {
+		for _, p := range result.Projects {
+			d.status[p.Name] = p
+		}
+	}
+	d.mu.Unlock()
+	d.requestRedraw()
+}
+
+// action é uma operação disparada por tecla sobre a linha selecionada.
+type action struct {
+	Verb string // Chave do catálogo de mensagens
+	Run  func(ctx context.Context, m *dcm.Manager, target string) (*dcm.Result, error)
+}
+
+var actions = map[rune]action{
+	'u': {Verb: "ui.starting", Run: func(ctx context.Context, m *dcm.Manager, target string) (*dcm.Result, error) {
+		return m.Up(ctx, target, dcm.UpOptions{})
+	}},
+	'd': {Verb: "ui.stopping", Run: func(ctx context.Context, m *dcm.Manager, target string) (*dcm.Result, error) {
+		return m.Down(ctx, target, dcm.DownOptions{})
+	}},
+	'r': {Verb: "ui.restarting", Run: func(ctx context.Context, m *dcm.Manager, target string) (*dcm.Result, error) {
+		return m.Restart(ctx, target)
+	}},
+}
+
+func (d *dashboard) runAction(a action, r row) {
+	d.mu.Lock()
+	if d.busy[r.Target] != "" {
+		d.mu.Unlock()
+		return
+	}
+	d.busy[r.Target] = i18n.T(a.Verb)
+	delete(d.errors, r.Target)
+	d.mu.Unlock()
+
+	d.actions.Add(1)
+	go func() {
+		defer d.actions.Done()
+		_, err := a.Run(d.ctx, d.manager, r.Target)
+
+		d.mu.Lock()
+		delete(d.busy, r.Target)
+		if err != nil {
+			d.errors[r.Target] = err.Error()
+		}
+		d.mu.Unlock()
+
+		d.refreshStatus()
+	}()
+}
+
+// paneWriter acumula as linhas dos logs no painel inferior.
+type paneWriter struct {
+	d       *dashboard
+	target  string
+	partial string
+}
+
+func (w *paneWriter) Write(p []byte) (int, error) {
+	w.d.mu.Lock()
+	text := w.partial + string(p)
+	lines := strings.Split(text, "\n")
+	w.partial = lines[len(lines)-1]
+	// Um stream já fechado pode entregar as últimas linhas depois que outro painel foi aberto
+	if w.d.logsTarget == w.target {
+		for _, line := range lines[:len(lines)-1] {
+			w.d.logs = appendLine(w.d.logs, line)
+		}
+	}
+	w.d.mu.Unlock()
+	w.d.requestRedraw()
+	return len(p), nil
+}
+
+// toggleLogs abre o painel de logs da linha selecionada ou fecha o painel aberto.
+func (d *dashboard) toggleLogs(r row) {
+	current := d.logsTarget
+	d.closeLogs()
+	if current == r.Target {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(d.ctx)
+	d.mu.Lock()
+	d.logsTarget = r.Target
+	d.logs = nil
+	d.stopLogs = cancel
+	d.mu.Unlock()
+
+	go func() {
+		opts := dcm.LogsOptions{Follow: true, Tail: logTail}
+		if err := d.manager.Logs(ctx, r.Target, opts, &paneWriter{d: d, target: r.Target}); err != nil && ctx.Err() == nil {
+			d.mu.Lock()
+			d.errors[r.Target] = err.Error()
+			d.mu.Unlock()
+			d.requestRedraw()
+		}
+	}()
+}
+
+func (d *dashboard) closeLogs() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.stopLogs != nil {
+		d.stopLogs()
+	}
+	d.stopLogs = nil
+	d.logsTarget = ""
+	d.logs = nil
+}
+
+// handleKey aplica a tecla ao estado. Retorna false quando o painel deve ser fechado.
+func (d *dashboard) handleKey(k key) bool {
+	d.mu.Lock()
+	var selected row
+	if len(d.rows) > 0 {
+		selected = d.rows[d.selected]
+	}
+	d.mu.Unlock()
+
+	switch k {
+	case keyQuit:
+		return false
+	case keyUp:
+		d.move(-1)
+	case keyDown:
+		d.move(1)
+	case keyEscape:
+		d.closeLogs()
+	case keyRefresh:
+		go d.refreshStatus()
+	default:
+		if len(d.rows) == 0 {
+			return true
+		}
+		if k == keyLogs {
+			d.toggleLogs(selected)
+		} else if a, ok := actions[rune(k)]; ok {
+			d.runAction(a, selected)
+		}
+	}
+	return true
+}
+
+func (d *dashboard) move(delta int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.rows) == 0 {
+		return
+	}
+	d.selected = (d.selected + delta + len(d.rows)) % len(d.rows)
+}
+
+type key rune
+
+const (
+	keyUp      key = -1
+	keyDown    key = -2
+	keyEscape  key = -3
+	keyQuit    key = 'q'
+	keyLogs    key = 'l'
+	keyRefresh key = 's'
+)
+
+// parseKey traduz uma leitura do terminal em modo raw para uma tecla do painel.
+func parseKey(b []byte) (key, bool) {
+	switch {
+	case len(b) == 0:
+		return 0, false
+	case string(b) == "\x1b[A" || string(b) == "\x1bOA":
+		return keyUp, true
+	case string(b) == "\x1b[B" || string(b) == "\x1bOB":
+		return keyDown, true
+	case b[0] == 0x1b:
+		if len(b) == 1 {
+			return keyEscape, true
+		}
+		return 0, false
+	case b[0] == 3: // Ctrl-C
+		return keyQuit, true
+	case b[0] == 'k':
+		return keyUp, true
+	case b[0] == 'j':
+		return keyDown, true
+	}
+	return key(b[0]), true
+}
+
+func readKeys(r io.Reader, keys chan<- key) {
+	buf := make([]byte, 16)
+	for {
+		n, err := r.Read(buf)
+		if err != nil {
+			close(keys)
+			return
+		}
+		if k, ok := parseKey(buf[:n]); ok {
+			keys <- k
+		}
+	}
+}
+
+func (d *dashboard) render(out io.Writer) {
+	width, height, err := term.GetSize(int(os.Stdin.Fd()))
+	if err != nil || width < 20 || height < 10 {
+		width, height = 80, 24
+	}
+
+	d.mu.Lock()
+	lines := d.view(width, height)
+	d.mu.Unlock()
+
+	var b strings.Builder
+	b.WriteString("\x1b[H\x1b[2J")
+	for i, line := range lines {
+		if i > 0 {
+			b.WriteString("\r\n")
+		}
+		b.WriteString(truncate(line, width))
+	}
+	io.WriteString(out, b.String())
+}
+
+// view monta as linhas da tela: cabeçalho, lista com rolagem e painel inferior (logs ou atividade).
+func (d *dashboard) view(width, height int) []string {
+	header := []string{
+		utils.Colorize("cyan", "DCM") + " " + d.ws.File,
+		i18n.T("ui.keys"),
+		strings.Repeat("─", width),
+	}
+
+	paneTitle := i18n.T("ui.activity")
+	pane := d.activity
+	if d.logsTarget != "" {
+		paneTitle = i18n.T("ui.logs_title", d.logsTarget)
+		pane = d.logs
+	}
+
+	paneHeight := height / 3
+	if d.logsTarget != "" {
+		paneHeight = height / 2
+	}
+	listHeight := height - len(header) - paneHeight - 1
+	if listHeight < 1 {
+		listHeight = 1
+	}
+
+	// Mantém a linha selecionada visível
+	start := 0
+	if d.selected >= listHeight {
+		start = d.selected - listHeight + 1
+	}
+
+	lines := append([]string{}, header...)
+	for i := start; i < len(d.rows) && i < start+listHeight; i++ {
+		lines = append(lines, d.rowLine(d.rows[i], i == d.selected))
+	}
+	for len(lines) < len(header)+listHeight {
+		lines = append(lines, "")
+	}
+
+	lines = append(lines, "── "+paneTitle+" "+strings.Repeat("─", max(0, width-len(paneTitle)-4)))
+	visible := paneHeight - 1
+	if len(pane) > visible {
+		pane = pane[len(pane)-visible:]
+	}
+	return append(lines, pane...)
+}
+
+func (d *dashboard) rowLine(r row, selected bool) string {
+	cursor := "  "
+	if selected {
+		cursor = utils.Colorize("cyan", "▶ ")
+	}
+
+	var line string
+	switch r.Kind {
+	case rowGroup:
+		line = fmt.Sprintf("%s%s %s", cursor, utils.Colorize("magenta", "◆"), r.Label)
+		if group, ok := d.ws.Groups[r.Target]; ok {
+			line += fmt.Sprintf("  (%s)", strings.Join(group.Services, ", "))
+		}
+	case rowProject:
+		state, color := d.projectState(r.Project)
+		line = fmt.Sprintf("%s%s %s  %s", cursor, utils.Colorize(color, "●"), r.Label, state)
+	case rowService:
+		state, color := d.serviceState(r.Project, r.Service)
+		line = fmt.Sprintf("%s    %s %s  %s", cursor, utils.Colorize(color, "●"), r.Label, state)
+	}
+
+	if verb := d.busy[r.Target]; verb != "" {
+		line += "  " + utils.Colorize("yellow", verb+"...")
+	}
+	if msg := d.errors[r.Target]; msg != "" {
+		line += "  " + utils.Colorize("red", "✗ "+firstLine(msg))
+	}
+	return line
+}
+
+func (d *dashboard) projectState(project string) (string, string) {
+	status, ok := d.status[project]
+	switch {
+	case !ok:
+		return "...", "blue"
+	case status.Error != "":
+		return i18n.T("ui.error", firstLine(status.Error)), "red"
+	case len(status.Containers) == 0:
+		return i18n.T("ui.stopped"), "blue"
+	}
+
+	running := 0
+	for _, c := range status.Containers {
+		if c.State == "running" {
+			running++
+		}
+	}
+	state := i18n.T("ui.running", running, len(status.Containers))
+	if running == len(status.Containers) {
+		return state, "green"
+	}
+	return state, "yellow"
+}
+
+func (d *dashboard) serviceState(project, service string) (string, string) {
+	status, ok := d.status[project]
+	if !ok || status.Error != "" {
+		return "", "blue"
+	}
+
+	for _, c := range status.Containers {
+		if c.Service != service {
+			continue
+		}
+		state := c.State
+		if c.Health != "" {
+			state += " (" + c.Health + ")"
+		}
+		switch {
+		case c.Health == "unhealthy" || c.State == "exited" || c.State == "dead":
+			return state, "red"
+		case c.State == "running" && (c.Health == "" || c.Health == "healthy"):
+			return state, "green"
+		}
+		return state, "yellow"
+	}
+	return i18n.T("ui.stopped"), "blue"
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
+
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+
+// truncate corta a linha na largura visível do terminal, sem contar as sequências de cor.
+func truncate(s string, width int) string {
+	if utf8.RuneCountInString(ansiPattern.ReplaceAllString(s, "")) <= width {
+		return s
+	}
+
+	var b strings.Builder
+	visible := 0
+	for len(s) > 0 && visible < width {
+		if loc := ansiPattern.FindStringIndex(s); loc != nil && loc[0] == 0 {
+			b.WriteString(s[:loc[1]])
+			s = s[loc[1]:]
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s)
+		b.WriteRune(r)
+		s = s[size:]
+		visible++
+	}
+	// Garante que uma cor aberta não vaze para a próxima linha
+	b.WriteString("\x1b[0m")
+	return b.String()
+}
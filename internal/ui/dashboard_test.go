@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Disneyjr/dcm/internal/workspace"
+	"github.com/Disneyjr/dcm/pkg/dcm"
+)
+
+func testWorkspace(t *testing.T) *workspace.Workspace {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "compose.yaml"), []byte("services:\n  web: {}\n  worker: {}\n"), 0644)
+
+	return &workspace.Workspace{
+		File: filepath.Join(dir, "workspace.json"),
+		Projects: map[string]workspace.Project{
+			"api": {Path: dir},
+			"db":  {Path: filepath.Join(dir, "missing")},
+		},
+		Groups: map[string]workspace.Group{"dev": {Services: []string{"api", "db"}}},
+	}
+}
+
+func TestBuildRows(t *testing.T) {
+	rows := buildRows(dcm.New(testWorkspace(t)))
+
+	var targets []string
+	for _, r := range rows {
+		targets = append(targets, r.Target)
+	}
+	expected := "dev api api:web api:worker db"
+	if strings.Join(targets, " ") != expected {
+		t.Errorf("expected rows %q, got %q", expected, strings.Join(targets, " "))
+	}
+	if rows[2].Kind != rowService || rows[2].Project != "api" || rows[2].Service != "web" {
+		t.Errorf("unexpected service row: %+v", rows[2])
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	cases := map[string]key{
+		"\x1b[A": keyUp,
+		"k":      keyUp,
+		"\x1b[B": keyDown,
+		"\x1b":   keyEscape,
+		"\x03":   keyQuit,
+		"u":      'u',
+	}
+	for input, expected := range cases {
+		if k, ok := parseKey([]byte(input)); !ok || k != expected {
+			t.Errorf("parseKey(%q) = %v, %v; expected %v", input, k, ok, expected)
+		}
+	}
+	if _, ok := parseKey([]byte("\x1b[5~")); ok {
+		t.Error("expected unknown escape sequence to be ignored")
+	}
+}
+
+func TestDashboardView(t *testing.T) {
+	d := newDashboard(context.Background(), dcm.New(testWorkspace(t)))
+	d.status["api"] = dcm.ProjectStatus{Name: "api", Containers: []dcm.ContainerInfo{
+		{Service: "web", State: "running", Health: "healthy"},
+		{Service: "worker", State: "exited"},
+	}}
+	d.errors["db"] = "projeto 'db' falhou\ndetalhes"
+	d.busy["dev"] = "iniciando"
+	d.activity = []string{"linha 1", "linha 2"}
+
+	view := strings.Join(d.view(120, 30), "\n")
+	for _, expected := range []string{"1/2 em execução", "running (healthy)", "exited", "iniciando...", "✗ projeto 'db' falhou", "linha 2"} {
+		if !strings.Contains(view, expected) {
+			t.Errorf("view is missing %q:\n%s", expected, view)
+		}
+	}
+	if strings.Contains(view, "detalhes") {
+		t.Error("expected only the first line of the error inline")
+	}
+}
+
+func TestDashboardShutdownWaitsForActions(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	d := newDashboard(ctx, dcm.New(testWorkspace(t)))
+
+	// Como um up interrompido: o compose ainda leva um tempo para encerrar depois do cancelamento
+	var finished atomic.Bool
+	slow := action{Verb: "ui.starting", Run: func(ctx context.Context, m *dcm.Manager, target string) (*dcm.Result, error) {
+		<-ctx.Done()
+		time.Sleep(50 * time.Millisecond)
+		finished.Store(true)
+		return nil, ctx.Err()
+	}}
+	d.runAction(slow, row{Kind: rowGroup, Target: "dev"})
+
+	d.shutdown(cancel, io.Discard)
+	if !finished.Load() {
+		t.Error("expected shutdown to wait for the running action")
+	}
+	if !strings.Contains(strings.Join(d.activity, "\n"), "Aguardando 1 ação(ões)") {
+		t.Errorf("expected a waiting message in the activity pane: %q", d.activity)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	colored := "\x1b[32mabcdef\x1b[0m"
+	if got := truncate(colored, 10); got != colored {
+		t.Errorf("expected line to fit, got %q", got)
+	}
+	if got := truncate(colored, 3); got != "\x1b[32mabc\x1b[0m" {
+		t.Errorf("unexpected truncation: %q", got)
+	}
+}
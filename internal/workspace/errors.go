@@ -0,0 +1,22 @@
+package workspace
+
+import (
+	"github.com/Disneyjr/dcm/internal/errclass"
+	"github.com/Disneyjr/dcm/internal/i18n"
+)
+
+// Classes de erro do carregamento do workspace, identificáveis com errors.Is
+// (o CLI usa para escolher o código de saída).
+var (
+	ErrNotFound error = i18n.Message("workspace.not_found")
+	ErrInvalid  error = i18n.Message("workspace.invalid")
+)
+
+func notFound(err error) error {
+	return errclass.Wrap(ErrNotFound, err)
+}
+
+// Invalid marca err como um problema de configuração do workspace (ErrInvalid).
+func Invalid(err error) error {
+	return errclass.Wrap(ErrInvalid, err)
+}
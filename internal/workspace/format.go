@@ -0,0 +1,107 @@
+package workspace
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
+	"github.com/Disneyjr/dcm/internal/i18n"
+)
+
+// FileNames lista os nomes aceitos para o arquivo do workspace, na ordem de precedência
+// usada quando mais de um existe no mesmo diretório.
+var FileNames = []string{"workspace.json", "workspace.yaml", "workspace.yml", "workspace.toml"}
+
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+	FormatTOML = "toml"
+)
+
+// FileFormat retorna o formato de um arquivo de workspace pela extensão.
+func FileFormat(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJSON, nil
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	case ".toml":
+		return FormatTOML, nil
+	}
+	return "", i18n.Errorf("workspace.unsupported_format", filepath.Base(path))
+}
+
+// decodeWorkspace preenche ws a partir do conteúdo do arquivo, reportando erros com linha e coluna.
+func decodeWorkspace(path string, data []byte, ws *Workspace) error {
+	format, err := FileFormat(path)
+	if err != nil {
+		return err
+	}
+
+	name := filepath.Base(path)
+	switch format {
+	case FormatYAML:
+		if err := yaml.Unmarshal(data, ws); err != nil {
+			return i18n.Errorf("workspace.parse_error", name, yamlErrorMessage(err))
+		}
+	case FormatTOML:
+		if _, err := toml.Decode(string(data), ws); err != nil {
+			var parseErr toml.ParseError
+			if errors.As(err, &parseErr) {
+				return i18n.Errorf("workspace.parse_error", name, i18n.T("workspace.position", parseErr.Position.Line, parseErr.Position.Col, parseErr.Message))
+			}
+			return i18n.Errorf("workspace.parse_error_wrap", name, err)
+		}
+	default:
+		if err := json.Unmarshal(data, ws); err != nil {
+			return i18n.Errorf("workspace.parse_error", name, jsonErrorPosition(data, err))
+		}
+	}
+	return nil
+}
+
+// jsonErrorPosition traduz o offset dos erros do encoding/json em linha e coluna.
+func jsonErrorPosition(data []byte, err error) string {
+	var offset int64
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+	default:
+		return err.Error()
+	}
+
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	col := int(offset) - bytes.LastIndexByte(before, '\n')
+	return i18n.T("workspace.position", line, col, err)
+}
+
+// yamlErrorMessage junta os erros de tipo do yaml.v3 (um por linha) em uma única mensagem.
+func yamlErrorMessage(err error) string {
+	messages := []string{strings.TrimPrefix(err.Error(), "yaml: ")}
+
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) {
+		messages = append([]string{}, typeErr.Errors...)
+	}
+
+	for i, msg := range messages {
+		if strings.HasPrefix(msg, "line ") {
+			messages[i] = i18n.T("workspace.line_prefix") + strings.TrimPrefix(msg, "line ")
+		}
+	}
+	return strings.Join(messages, "; ")
+}
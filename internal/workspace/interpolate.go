@@ -0,0 +1,196 @@
+package workspace
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/Disneyjr/dcm/internal/i18n"
+)
+
+// DotEnvFile é lido do mesmo diretório do arquivo do workspace, se existir.
+const DotEnvFile = ".env"
+
+// lookupFunc resolve o valor de uma variável; ok=false quando ela não está definida.
+type lookupFunc func(name string) (string, bool)
+
+// parseDotEnv lê linhas KEY=VALUE, ignorando comentários, linhas vazias e o prefixo "export".
+func parseDotEnv(data []byte) (map[string]string, error) {
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, found := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return nil, i18n.Errorf("dotenv.invalid_line", lineNum)
+		}
+
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		values[key] = value
+	}
+	return values, scanner.Err()
+}
+
+// envLookup consulta primeiro o ambiente do processo e depois o .env, como o docker compose faz.
+func envLookup(baseDir string) (lookupFunc, error) {
+	dotEnv := map[string]string{}
+
+	data, err := os.ReadFile(filepath.Join(baseDir, DotEnvFile))
+	if err == nil {
+		if dotEnv, err = parseDotEnv(data); err != nil {
+			return nil, i18n.Errorf("dotenv.read_error", DotEnvFile, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, i18n.Errorf("dotenv.read_error", DotEnvFile, err)
+	}
+
+	return func(name string) (string, bool) {
+		if value, ok := os.LookupEnv(name); ok {
+			return value, true
+		}
+		value, ok := dotEnv[name]
+		return value, ok
+	}, nil
+}
+
+// interpolate substitui ${VAR}, ${VAR:-padrão} e ${VAR:?mensagem} em s. "$$" produz um "$" literal.
+func interpolate(s string, lookup lookupFunc) (string, error) {
+	if !strings.Contains(s, "$") {
+		return s, nil
+	}
+
+	var out strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' || i+1 >= len(s) {
+			out.WriteByte(s[i])
+			continue
+		}
+
+		switch s[i+1] {
+		case '$':
+			out.WriteByte('$')
+			i++
+			continue
+		case '{':
+		default:
+			out.WriteByte(s[i])
+			continue
+		}
+
+		end := strings.IndexByte(s[i:], '}')
+		if end < 0 {
+			return "", i18n.Errorf("interpolate.unclosed", s)
+		}
+		expr := s[i+2 : i+end]
+		i += end
+
+		value, err := expandExpression(expr, lookup)
+		if err != nil {
+			return "", err
+		}
+		out.WriteString(value)
+	}
+	return out.String(), nil
+}
+
+func expandExpression(expr string, lookup lookupFunc) (string, error) {
+	name, operand, op := expr, "", ""
+	if idx := strings.Index(expr, ":"); idx >= 0 && idx+1 < len(expr) && (expr[idx+1] == '-' || expr[idx+1] == '?') {
+		name, op, operand = expr[:idx], expr[idx:idx+2], expr[idx+2:]
+	}
+
+	if name == "" || strings.ContainsAny(name, " :${}") {
+		return "", i18n.Errorf("interpolate.invalid_variable", expr)
+	}
+
+	value, ok := lookup(name)
+	switch op {
+	case ":-":
+		if !ok || value == "" {
+			return operand, nil
+		}
+	case ":?":
+		if !ok || value == "" {
+			if operand == "" {
+				operand = i18n.T("interpolate.not_set")
+			}
+			return "", i18n.Errorf("interpolate.required", name, operand)
+		}
+	}
+	return value, nil
+}
+
+// interpolateWorkspace aplica a interpolação em todos os campos texto do workspace.
+func interpolateWorkspace(ws *Workspace, lookup lookupFunc) error {
+	return interpolateValue(reflect.ValueOf(ws).Elem(), "", lookup)
+}
+
+func interpolateValue(v reflect.Value, path string, lookup lookupFunc) error {
+	switch v.Kind() {
+	case reflect.String:
+		result, err := interpolate(v.String(), lookup)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		v.SetString(result)
+
+	case reflect.Pointer:
+		if !v.IsNil() {
+			return interpolateValue(v.Elem(), path, lookup)
+		}
+
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			if err := interpolateValue(v.Index(i), fmt.Sprintf("%s[%d]", path, i), lookup); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Map:
+		// Valores de map não são endereçáveis: interpolamos uma cópia e gravamos de volta
+		iter := v.MapRange()
+		for iter.Next() {
+			elem := reflect.New(iter.Value().Type()).Elem()
+			elem.Set(iter.Value())
+			if err := interpolateValue(elem, joinPath(path, iter.Key().String()), lookup); err != nil {
+				return err
+			}
+			v.SetMapIndex(iter.Key(), elem)
+		}
+
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			field := t.Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" || !field.IsExported() {
+				continue
+			}
+			if err := interpolateValue(v.Field(i), joinPath(path, name), lookup); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,77 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestInterpolate(t *testing.T) {
+	vars := map[string]string{"HOME_DIR": "/home/dev", "EMPTY": ""}
+	lookup := func(name string) (string, bool) {
+		value, ok := vars[name]
+		return value, ok
+	}
+
+	cases := map[string]string{
+		"${HOME_DIR}/repos":        "/home/dev/repos",
+		"${MISSING:-./default}":    "./default",
+		"${EMPTY:-fallback}":       "fallback",
+		"${HOME_DIR:?obrigatória}": "/home/dev",
+		"preço $$10":               "preço $10",
+		"sem variáveis":            "sem variáveis",
+		"$HOME_DIR":                "$HOME_DIR",
+	}
+	for input, expected := range cases {
+		result, err := interpolate(input, lookup)
+		if err != nil {
+			t.Errorf("%q: unexpected error %v", input, err)
+			continue
+		}
+		if result != expected {
+			t.Errorf("%q: expected %q, got %q", input, expected, result)
+		}
+	}
+
+	for _, input := range []string{"${MISSING:?defina MISSING}", "${EMPTY:?}", "${UNCLOSED", "${}"} {
+		if _, err := interpolate(input, lookup); err == nil {
+			t.Errorf("%q: expected error, got nil", input)
+		}
+	}
+}
+
+func TestLoadWorkspaceInterpolation(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	content := `{
+  "version": "1.0",
+  "projects": {
+    "api": { "path": "${DCM_TEST_REPOS}/api", "description": "${DCM_TEST_DESC:-API}" }
+  }
+}`
+	os.WriteFile("workspace.json", []byte(content), 0644)
+	os.WriteFile(".env", []byte("# repositórios locais\nDCM_TEST_REPOS=./from-dotenv\nexport DCM_TEST_DESC='Minha API'\n"), 0644)
+
+	ws := NewWorkspace()
+	if err := LoadWorkspace(ws); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	api := ws.Projects["api"]
+	if api.Path != filepath.Join(dir, "from-dotenv", "api") {
+		t.Errorf("expected .env value resolved against base dir, got %s", api.Path)
+	}
+	if api.Description != "Minha API" {
+		t.Errorf("expected quoted .env value, got %q", api.Description)
+	}
+
+	// O ambiente do processo tem precedência sobre o .env
+	t.Setenv("DCM_TEST_REPOS", "/opt/repos")
+	ws = NewWorkspace()
+	if err := LoadWorkspace(ws); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if ws.Projects["api"].Path != filepath.Join("/opt/repos", "api") {
+		t.Errorf("expected environment to override .env, got %s", ws.Projects["api"].Path)
+	}
+}
@@ -0,0 +1,211 @@
+package workspace
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
+	"github.com/Disneyjr/dcm/internal/i18n"
+)
+
+// LocalFileNames são os arquivos de override pessoais (fora do git), procurados ao lado do workspace.
+var LocalFileNames = []string{"workspace.local.json", "workspace.local.yaml", "workspace.local.yml", "workspace.local.toml"}
+
+func findLocalOverride(baseDir string) string {
+	for _, name := range LocalFileNames {
+		path := filepath.Join(baseDir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// decodeGeneric lê o arquivo como um documento genérico, preservando quais chaves estão presentes
+// (e quais foram definidas como null) para o merge.
+func decodeGeneric(path string, data []byte) (map[string]any, error) {
+	format, err := FileFormat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := map[string]any{}
+	switch format {
+	case FormatYAML:
+		err = yaml.Unmarshal(data, &doc)
+	case FormatTOML:
+		_, err = toml.Decode(string(data), &doc)
+	default:
+		err = json.Unmarshal(data, &doc)
+	}
+	if err != nil {
+		return nil, i18n.Errorf("workspace.parse_error_wrap", filepath.Base(path), err)
+	}
+	return doc, nil
+}
+
+// mergeDocuments aplica src sobre dst: objetos são mesclados recursivamente, valores simples e
+// listas substituem o anterior e null remove a chave herdada. sources registra, para cada valor
+// final, o arquivo que o definiu.
+func mergeDocuments(dst, src map[string]any, prefix, file string, sources map[string]string) {
+	for key, value := range src {
+		path := joinPath(prefix, key)
+
+		if value == nil {
+			delete(dst, key)
+			forgetSources(sources, path)
+			continue
+		}
+
+		if srcMap, ok := value.(map[string]any); ok {
+			dstMap, ok := dst[key].(map[string]any)
+			if !ok {
+				forgetSources(sources, path)
+				dstMap = map[string]any{}
+				dst[key] = dstMap
+			}
+			mergeDocuments(dstMap, srcMap, path, file, sources)
+			continue
+		}
+
+		forgetSources(sources, path)
+		dst[key] = value
+		sources[path] = file
+	}
+}
+
+func forgetSources(sources map[string]string, path string) {
+	for key := range sources {
+		if key == path || strings.HasPrefix(key, path+".") {
+			delete(sources, key)
+		}
+	}
+}
+
+// mergeValue aplica sobre dst os campos de src presentes em doc, o documento genérico do mesmo
+// arquivo: os valores já tipados vêm de src, e doc só diz quais chaves o arquivo definiu ou anulou.
+// Assim um escalar como "version: 1.0" em YAML chega ao campo string com o texto original.
+func mergeValue(dst, src reflect.Value, doc map[string]any) {
+	switch dst.Kind() {
+	case reflect.Pointer:
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		if !src.IsNil() {
+			mergeValue(dst.Elem(), src.Elem(), doc)
+		}
+
+	case reflect.Map:
+		if dst.IsNil() {
+			dst.Set(reflect.MakeMap(dst.Type()))
+		}
+		for key, value := range doc {
+			k := reflect.ValueOf(key)
+			if value == nil {
+				dst.SetMapIndex(k, reflect.Value{})
+				continue
+			}
+			srcElem := src.MapIndex(k)
+			if !srcElem.IsValid() {
+				continue
+			}
+			// Valores de map não são endereçáveis: mesclamos uma cópia e gravamos de volta
+			elem := reflect.New(srcElem.Type()).Elem()
+			if sub, ok := value.(map[string]any); ok && mergeable(elem) {
+				if current := dst.MapIndex(k); current.IsValid() {
+					elem.Set(current)
+				}
+				mergeValue(elem, srcElem, sub)
+			} else {
+				elem.Set(srcElem)
+			}
+			dst.SetMapIndex(k, elem)
+		}
+
+	case reflect.Struct:
+		for key, value := range doc {
+			i := fieldIndex(dst.Type(), key)
+			if i < 0 {
+				continue
+			}
+			field := dst.Field(i)
+			if value == nil {
+				field.SetZero()
+				continue
+			}
+			if sub, ok := value.(map[string]any); ok && mergeable(field) {
+				mergeValue(field, src.Field(i), sub)
+				continue
+			}
+			field.Set(src.Field(i))
+		}
+	}
+}
+
+// mergeable indica se o campo é um objeto mesclado chave a chave (struct, map ou ponteiro para struct).
+func mergeable(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Struct, reflect.Map:
+		return true
+	case reflect.Pointer:
+		return v.Type().Elem().Kind() == reflect.Struct
+	}
+	return false
+}
+
+// fieldIndex encontra o campo pela tag json (a mesma nos três formatos). Como os decoders de JSON e
+// TOML, aceita a chave com outra capitalização quando não há uma correspondência exata.
+func fieldIndex(t reflect.Type, key string) int {
+	fallback := -1
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if name == key {
+			return i
+		}
+		if fallback < 0 && strings.EqualFold(name, key) {
+			fallback = i
+		}
+	}
+	return fallback
+}
+
+// loadMerged lê e mescla os arquivos na ordem informada. Cada arquivo é decodificado no Workspace
+// tipado, para que erros de tipo apontem o arquivo e a linha corretos, e os valores tipados é que
+// são mesclados.
+func loadMerged(files []string, baseDir string, ws *Workspace) (map[string]string, error) {
+	merged := map[string]any{}
+	sources := map[string]string{}
+
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, i18n.Errorf("workspace.read_error", path, err)
+		}
+
+		var file Workspace
+		if err := decodeWorkspace(path, data, &file); err != nil {
+			return nil, err
+		}
+		doc, err := decodeGeneric(path, data)
+		if err != nil {
+			return nil, err
+		}
+		mergeValue(reflect.ValueOf(ws).Elem(), reflect.ValueOf(file), doc)
+
+		display := path
+		if rel, err := filepath.Rel(baseDir, path); err == nil && !strings.HasPrefix(rel, "..") {
+			display = rel
+		}
+		mergeDocuments(merged, doc, "", display, sources)
+	}
+	return sources, nil
+}
@@ -0,0 +1,115 @@
+package workspace
+
+import (
+	"os"
+	"testing"
+)
+
+func TestLoadWorkspaceLocalOverride(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	base := `{
+  "version": "1.0",
+  "projects": {
+    "api": { "path": "./api", "description": "API" },
+    "docs": { "path": "./docs" }
+  },
+  "groups": {
+    "dev": { "services": ["api", "docs"], "parallel": true }
+  }
+}`
+	local := `{
+  "projects": {
+    "api": { "path": "/home/dev/api" },
+    "docs": null
+  },
+  "groups": {
+    "dev": { "services": ["api"] },
+    "mine": { "services": ["api"] }
+  }
+}`
+	extra := "[groups.dev]\nparallel = false\n"
+
+	os.WriteFile("workspace.json", []byte(base), 0644)
+	os.WriteFile("workspace.local.json", []byte(local), 0644)
+	os.WriteFile("ci.toml", []byte(extra), 0644)
+
+	ws := NewWorkspace()
+	if err := LoadWorkspace(ws, "ci.toml"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	api := ws.Projects["api"]
+	if api.Path != "/home/dev/api" || api.Description != "API" {
+		t.Errorf("expected deep merge of api project, got %+v", api)
+	}
+	if _, exists := ws.Projects["docs"]; exists {
+		t.Error("expected docs to be removed by null in the local override")
+	}
+
+	dev := ws.Groups["dev"]
+	if len(dev.Services) != 1 || dev.Parallel == nil || *dev.Parallel {
+		t.Errorf("expected lists replaced and parallel from -f file, got %+v", dev)
+	}
+	if _, exists := ws.Groups["mine"]; !exists {
+		t.Error("expected private group from the local override")
+	}
+
+	expectedSources := map[string]string{
+		"version":                  "workspace.json",
+		"projects.api.description": "workspace.json",
+		"projects.api.path":        "workspace.local.json",
+		"groups.dev.services":      "workspace.local.json",
+		"groups.dev.parallel":      "ci.toml",
+	}
+	for key, file := range expectedSources {
+		if ws.Sources[key] != file {
+			t.Errorf("expected %s from %s, got %q", key, file, ws.Sources[key])
+		}
+	}
+	if _, exists := ws.Sources["projects.docs.path"]; exists {
+		t.Error("expected sources of removed project to be dropped")
+	}
+}
+
+func TestLoadWorkspaceMissingExtraFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+	os.WriteFile("workspace.json", []byte(`{"version": "1.0"}`), 0644)
+
+	if err := LoadWorkspace(NewWorkspace(), "missing.json"); err == nil {
+		t.Error("expected error for missing -f file, got nil")
+	}
+}
+
+func TestLoadWorkspaceYAMLMergeScalars(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	// Escalares sem aspas são aceitos em campos texto e precisam manter o texto original no merge
+	base := "version: 1.0\nprojects:\n  api:\n    path: ./api\n    description: 123\n    readiness:\n      type: tcp\n      address: localhost:5432\n"
+	local := "projects:\n  api:\n    description: 4.50\n    readiness:\n      timeout: 90s\n    timeouts:\n      up: 5m\ngroups:\n  dev:\n    services: [api]\n    maxParallel: 2\n"
+
+	os.WriteFile("workspace.yaml", []byte(base), 0644)
+	os.WriteFile("workspace.local.yaml", []byte(local), 0644)
+
+	ws := NewWorkspace()
+	if err := LoadWorkspace(ws); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if ws.Version != "1.0" {
+		t.Errorf("expected version %q, got %q", "1.0", ws.Version)
+	}
+	api := ws.Projects["api"]
+	if api.Description != "4.50" || api.Timeouts["up"] != "5m" {
+		t.Errorf("expected merged api project, got %+v", api)
+	}
+	if api.Readiness == nil || api.Readiness.Address != "localhost:5432" || api.Readiness.Timeout != "90s" {
+		t.Errorf("expected deep merge of readiness, got %+v", api.Readiness)
+	}
+	if dev := ws.Groups["dev"]; dev.MaxParallel != 2 || len(dev.Services) != 1 {
+		t.Errorf("unexpected dev group: %+v", dev)
+	}
+	if ws.Sources["projects.api.description"] != "workspace.local.yaml" {
+		t.Errorf("unexpected source for description: %q", ws.Sources["projects.api.description"])
+	}
+}
@@ -1,28 +1,184 @@
 package workspace
 
 import (
-	"encoding/json"
-	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Disneyjr/dcm/internal/i18n"
 )
 
 type Project struct {
-	Path        string `json:"path"`
-	Description string `json:"description"`
+	Path         string                    `json:"path" yaml:"path" toml:"path"`
+	Description  string                    `json:"description" yaml:"description" toml:"description"`
+	DependsOn    []string                  `json:"dependsOn,omitempty" yaml:"dependsOn,omitempty" toml:"dependsOn,omitempty"`          // Projetos ou specs "projeto:serviço" que precisam subir antes
+	Services     map[string]ServiceOptions `json:"services,omitempty" yaml:"services,omitempty" toml:"services,omitempty"`             // Configurações por serviço do docker-compose
+	Readiness    *Readiness                `json:"readiness,omitempty" yaml:"readiness,omitempty" toml:"readiness,omitempty"`          // Como saber que o projeto está pronto depois do "up"
+	ComposeFiles []string                  `json:"composeFiles,omitempty" yaml:"composeFiles,omitempty" toml:"composeFiles,omitempty"` // -f (relativos à pasta do projeto)
+	ProjectName  string                    `json:"projectName,omitempty" yaml:"projectName,omitempty" toml:"projectName,omitempty"`    // -p
+	Profiles     []string                  `json:"profiles,omitempty" yaml:"profiles,omitempty" toml:"profiles,omitempty"`             // --profile
+	EnvFile      string                    `json:"envFile,omitempty" yaml:"envFile,omitempty" toml:"envFile,omitempty"`                // --env-file (relativo à pasta do projeto)
+	Timeouts     map[string]string         `json:"timeouts,omitempty" yaml:"timeouts,omitempty" toml:"timeouts,omitempty"`             // Tempo limite por operação (ex: "up": "5m")
+	Tasks        map[string]Task           `json:"tasks,omitempty" yaml:"tasks,omitempty" toml:"tasks,omitempty"`                      // Comandos nomeados, executados com "dcm task"
+}
+
+// TimeoutOperations são as operações que aceitam tempo limite em "timeouts".
+var TimeoutOperations = []string{"up", "down", "restart", "pull", "build"}
+
+// Timeout devolve o tempo limite da operação no projeto; zero quando não há limite.
+func (p Project) Timeout(operation string) (time.Duration, error) {
+	value, ok := p.Timeouts[operation]
+	if !ok {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, i18n.Errorf("workspace.invalid_timeout_for", operation, value)
+	}
+	return d, nil
+}
+
+// ValidateTimeouts confere se cada operação de "timeouts" é conhecida e tem uma duração válida.
+func (p Project) ValidateTimeouts() error {
+	operations := make([]string, 0, len(p.Timeouts))
+	for operation := range p.Timeouts {
+		operations = append(operations, operation)
+	}
+	sort.Strings(operations)
+
+	for _, operation := range operations {
+		if !slices.Contains(TimeoutOperations, operation) {
+			return i18n.Errorf("workspace.unknown_timeout", operation, strings.Join(TimeoutOperations, ", "))
+		}
+		if _, err := p.Timeout(operation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Task é um comando nomeado do projeto (ex: "migrate"), executado em um serviço com "dcm task".
+type Task struct {
+	Service     string   `json:"service" yaml:"service" toml:"service"`                   // Serviço do docker-compose em que o comando roda
+	Command     []string `json:"command" yaml:"command" toml:"command"`                   // Ex: ["npm", "run", "migrate"]
+	Run         bool     `json:"run,omitempty" yaml:"run,omitempty" toml:"run,omitempty"` // Usa um container novo ("compose run --rm") em vez do que está rodando
+	Description string   `json:"description,omitempty" yaml:"description,omitempty" toml:"description,omitempty"`
+}
+
+// Validate verifica se os campos obrigatórios da tarefa foram preenchidos.
+func (t Task) Validate() error {
+	if t.Service == "" {
+		return i18n.Errorf("task.requires", "service")
+	}
+	if len(t.Command) == 0 {
+		return i18n.Errorf("task.requires", "command")
+	}
+	return nil
+}
+
+type ServiceOptions struct {
+	DependsOn []string `json:"dependsOn,omitempty" yaml:"dependsOn,omitempty" toml:"dependsOn,omitempty"`
+}
+
+const (
+	ReadinessHealthy = "healthy" // healthcheck do compose
+	ReadinessTCP     = "tcp"
+	ReadinessHTTP    = "http"
+	ReadinessCommand = "command"
+
+	DefaultReadinessTimeout  = 60 * time.Second
+	DefaultReadinessInterval = 2 * time.Second
+)
+
+type Readiness struct {
+	Type     string `json:"type" yaml:"type" toml:"type"`
+	Address  string `json:"address,omitempty" yaml:"address,omitempty" toml:"address,omitempty"`    // host:porta para "tcp"
+	URL      string `json:"url,omitempty" yaml:"url,omitempty" toml:"url,omitempty"`                // URL que deve responder 2xx para "http"
+	Command  string `json:"command,omitempty" yaml:"command,omitempty" toml:"command,omitempty"`    // Comando executado no diretório do projeto para "command"
+	Timeout  string `json:"timeout,omitempty" yaml:"timeout,omitempty" toml:"timeout,omitempty"`    // Ex: "90s", padrão 60s
+	Interval string `json:"interval,omitempty" yaml:"interval,omitempty" toml:"interval,omitempty"` // Ex: "500ms", padrão 2s
+}
+
+// Durations interpreta timeout e interval, aplicando os valores padrão quando omitidos.
+func (r Readiness) Durations() (time.Duration, time.Duration, error) {
+	timeout, interval := DefaultReadinessTimeout, DefaultReadinessInterval
+
+	if r.Timeout != "" {
+		d, err := time.ParseDuration(r.Timeout)
+		if err != nil || d <= 0 {
+			return 0, 0, i18n.Errorf("readiness.invalid_timeout", r.Timeout)
+		}
+		timeout = d
+	}
+	if r.Interval != "" {
+		d, err := time.ParseDuration(r.Interval)
+		if err != nil || d <= 0 {
+			return 0, 0, i18n.Errorf("readiness.invalid_interval", r.Interval)
+		}
+		interval = d
+	}
+	return timeout, interval, nil
+}
+
+// Validate verifica se o tipo é conhecido e se o campo exigido por ele foi preenchido.
+func (r Readiness) Validate() error {
+	switch r.Type {
+	case ReadinessHealthy:
+	case ReadinessTCP:
+		if r.Address == "" {
+			return i18n.Errorf("readiness.requires", "tcp", "address")
+		}
+	case ReadinessHTTP:
+		if r.URL == "" {
+			return i18n.Errorf("readiness.requires", "http", "url")
+		}
+	case ReadinessCommand:
+		if r.Command == "" {
+			return i18n.Errorf("readiness.requires", "command", "command")
+		}
+	default:
+		return i18n.Errorf("readiness.unknown_type", r.Type)
+	}
+
+	_, _, err := r.Durations()
+	return err
 }
 
 type Group struct {
-	Services []string `json:"services"`
-	Extends  string   `json:"extends,omitempty"`
-	Parallel *bool    `json:"parallel,omitempty"` // Use pointer to distinguish between false and not set
+	Services    []string `json:"services" yaml:"services" toml:"services"`
+	Extends     string   `json:"extends,omitempty" yaml:"extends,omitempty" toml:"extends,omitempty"`
+	Parallel    *bool    `json:"parallel,omitempty" yaml:"parallel,omitempty" toml:"parallel,omitempty"`          // Use pointer to distinguish between false and not set
+	MaxParallel int      `json:"maxParallel,omitempty" yaml:"maxParallel,omitempty" toml:"maxParallel,omitempty"` // Máximo de serviços subindo ao mesmo tempo (0 = sem limite)
+	OnFailure   string   `json:"onFailure,omitempty" yaml:"onFailure,omitempty" toml:"onFailure,omitempty"`       // continue, stop ou rollback (padrão: continue)
+}
+
+// Políticas de falha de um grupo no "up".
+const (
+	OnFailureContinue = "continue" // Continua iniciando os demais serviços
+	OnFailureStop     = "stop"     // Não inicia mais nada depois da primeira falha
+	OnFailureRollback = "rollback" // Para tudo o que esta execução iniciou
+)
+
+// ValidateOnFailure confere se a política de falha é conhecida. Vazio equivale a "continue".
+func ValidateOnFailure(policy string) error {
+	switch policy {
+	case "", OnFailureContinue, OnFailureStop, OnFailureRollback:
+		return nil
+	}
+	return i18n.Errorf("workspace.invalid_on_failure", policy, OnFailureContinue, OnFailureStop, OnFailureRollback)
 }
 
 type Workspace struct {
-	Version  string             `json:"version"`
-	Projects map[string]Project `json:"projects"`
-	Groups   map[string]Group   `json:"groups"`
-	BaseDir  string             `json:"-"` // Diretório base do workspace (onde o workspace.json foi encontrado)
+	Version  string             `json:"version" yaml:"version" toml:"version"`
+	Engine   string             `json:"engine,omitempty" yaml:"engine,omitempty" toml:"engine,omitempty"` // docker, docker-compose, podman-compose ou nerdctl (vazio = detecção automática)
+	Projects map[string]Project `json:"projects" yaml:"projects" toml:"projects"`
+	Groups   map[string]Group   `json:"groups" yaml:"groups" toml:"groups"`
+	BaseDir  string             `json:"-" yaml:"-" toml:"-"` // Diretório base do workspace (onde o workspace.json foi encontrado)
+	File     string             `json:"-" yaml:"-" toml:"-"` // Caminho do arquivo carregado (json, yaml ou toml)
+	Sources  map[string]string  `json:"-" yaml:"-" toml:"-"` // Arquivo que definiu cada valor (ex: "projects.api.path")
 }
 
 func NewWorkspace() *Workspace {
@@ -36,9 +192,11 @@ func findWorkspaceFile() (string, string, error) {
 	}
 
 	for {
-		path := filepath.Join(curr, "workspace.json")
-		if _, err := os.Stat(path); err == nil {
-			return path, curr, nil
+		for _, name := range FileNames {
+			path := filepath.Join(curr, name)
+			if _, err := os.Stat(path); err == nil {
+				return path, curr, nil
+			}
 		}
 
 		parent := filepath.Dir(curr)
@@ -48,25 +206,81 @@ func findWorkspaceFile() (string, string, error) {
 		curr = parent
 	}
 
-	return "", "", fmt.Errorf("workspace.json não encontrado nos diretórios pais")
+	return "", "", notFound(i18n.Errorf("workspace.not_found_in_parents"))
 }
 
-func LoadWorkspace(ws *Workspace) error {
+// LoadWorkspace carrega o workspace mais próximo, mescla o workspace.local (se existir) e depois
+// os arquivos extras na ordem informada (ex: flags -f).
+func LoadWorkspace(ws *Workspace, extraFiles ...string) error {
 	path, baseDir, err := findWorkspaceFile()
 	if err != nil {
 		return err
 	}
+	return loadWorkspaceFrom(ws, path, baseDir, extraFiles)
+}
 
-	data, err := os.ReadFile(path)
+// LoadWorkspaceFile carrega um workspace explícito (flag --workspace) em vez de procurar nos
+// diretórios pais. path pode ser o arquivo ou o diretório que o contém.
+func LoadWorkspaceFile(ws *Workspace, path string, extraFiles ...string) error {
+	abs, err := filepath.Abs(path)
 	if err != nil {
-		return fmt.Errorf("não foi possível ler %s: %w", path, err)
+		return err
 	}
 
-	if err := json.Unmarshal(data, ws); err != nil {
-		return fmt.Errorf("erro ao parsear workspace.json: %w", err)
+	info, err := os.Stat(abs)
+	if err != nil {
+		return notFound(i18n.Errorf("workspace.path_not_found", path))
 	}
+	if info.IsDir() {
+		file := ""
+		for _, name := range FileNames {
+			if _, err := os.Stat(filepath.Join(abs, name)); err == nil {
+				file = filepath.Join(abs, name)
+				break
+			}
+		}
+		if file == "" {
+			return notFound(i18n.Errorf("workspace.none_in_dir", path, strings.Join(FileNames, ", ")))
+		}
+		abs = file
+	}
+
+	return loadWorkspaceFrom(ws, abs, filepath.Dir(abs), extraFiles)
+}
+
+func loadWorkspaceFrom(ws *Workspace, path, baseDir string, extraFiles []string) error {
+	files := []string{path}
+	if local := findLocalOverride(baseDir); local != "" {
+		files = append(files, local)
+	}
+	for _, file := range extraFiles {
+		abs, err := filepath.Abs(file)
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stat(abs); err != nil {
+			return notFound(i18n.Errorf("workspace.file_not_found", file))
+		}
+		files = append(files, abs)
+	}
+
+	sources, err := loadMerged(files, baseDir, ws)
+	if err != nil {
+		return Invalid(err)
+	}
+	ws.Sources = sources
 
 	ws.BaseDir = baseDir
+	ws.File = path
+
+	// A interpolação acontece antes da resolução dos caminhos, para que ${VAR} possa conter caminhos relativos
+	lookup, err := envLookup(baseDir)
+	if err != nil {
+		return Invalid(err)
+	}
+	if err := interpolateWorkspace(ws, lookup); err != nil {
+		return Invalid(i18n.Errorf("workspace.interpolate_error", filepath.Base(path), err))
+	}
 
 	// Resolver caminhos dos projetos relativos ao BaseDir
 	for name, proj := range ws.Projects {
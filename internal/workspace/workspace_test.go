@@ -1,8 +1,12 @@
 package workspace
 
 import (
+	"errors"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestLoadWorkspace(t *testing.T) {
@@ -35,3 +39,164 @@ func TestLoadWorkspace(t *testing.T) {
 		t.Errorf("expected project 'test' to exist")
 	}
 }
+
+func TestLoadWorkspaceFormats(t *testing.T) {
+	files := map[string]string{
+		"workspace.yaml": `version: "1.0"
+# comentários são permitidos
+projects:
+  api:
+    path: ./api
+    dependsOn: [db]
+  db:
+    path: ./db
+groups:
+  dev:
+    services: [api]
+    parallel: false
+`,
+		"workspace.toml": `version = "1.0"
+
+[projects.api]
+path = "./api"
+dependsOn = ["db"]
+
+[projects.db]
+path = "./db"
+
+[groups.dev]
+services = ["api"]
+parallel = false
+`,
+	}
+
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			t.Chdir(dir)
+			if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+				t.Fatalf("failed to write %s: %v", name, err)
+			}
+
+			ws := NewWorkspace()
+			if err := LoadWorkspace(ws); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			api, ok := ws.Projects["api"]
+			if !ok || len(api.DependsOn) != 1 || api.DependsOn[0] != "db" {
+				t.Errorf("unexpected api project: %+v", api)
+			}
+			if api.Path != filepath.Join(dir, "api") {
+				t.Errorf("expected path resolved against base dir, got %s", api.Path)
+			}
+			if group := ws.Groups["dev"]; group.Parallel == nil || *group.Parallel {
+				t.Errorf("expected dev group to be sequential: %+v", group)
+			}
+		})
+	}
+}
+
+func TestLoadWorkspacePrecedence(t *testing.T) {
+	t.Chdir(t.TempDir())
+	os.WriteFile("workspace.toml", []byte(`version = "toml"`), 0644)
+	os.WriteFile("workspace.yaml", []byte(`version: "yaml"`), 0644)
+
+	ws := NewWorkspace()
+	if err := LoadWorkspace(ws); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if ws.Version != "yaml" {
+		t.Errorf("expected workspace.yaml to take precedence over workspace.toml, got %s", ws.Version)
+	}
+}
+
+func TestLoadWorkspaceErrorPosition(t *testing.T) {
+	files := map[string]string{
+		"workspace.json": "{\n  \"version\": \"1.0\",\n  \"projects\": [1]\n}",
+		"workspace.yaml": "version: \"1.0\"\nprojects:\n  - api\n",
+		"workspace.toml": "version = \"1.0\"\nprojects = \n",
+	}
+
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			os.WriteFile(name, []byte(content), 0644)
+
+			err := LoadWorkspace(NewWorkspace())
+			if err == nil {
+				t.Fatal("expected parse error, got nil")
+			}
+			if !strings.Contains(err.Error(), name) || !strings.Contains(err.Error(), "linha ") {
+				t.Errorf("expected file name and line in error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestLoadWorkspaceFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "workspace.yaml"), []byte("version: \"1.0\"\nprojects:\n  api:\n    path: ./api\n"), 0644)
+
+	// Pode receber o diretório ou o próprio arquivo, independentemente do diretório atual
+	for _, path := range []string{dir, filepath.Join(dir, "workspace.yaml")} {
+		ws := NewWorkspace()
+		if err := LoadWorkspaceFile(ws, path); err != nil {
+			t.Fatalf("LoadWorkspaceFile(%s): %v", path, err)
+		}
+		if ws.BaseDir != dir {
+			t.Errorf("expected base dir %s, got %s", dir, ws.BaseDir)
+		}
+		if ws.Projects["api"].Path != filepath.Join(dir, "api") {
+			t.Errorf("unexpected project path: %s", ws.Projects["api"].Path)
+		}
+	}
+
+	if err := LoadWorkspaceFile(NewWorkspace(), t.TempDir()); err == nil {
+		t.Error("expected error for directory without workspace, got nil")
+	}
+}
+
+func TestLoadWorkspaceErrorClasses(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	err := LoadWorkspace(NewWorkspace())
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	os.WriteFile("workspace.json", []byte(`{"projects": {`), 0644)
+	err = LoadWorkspace(NewWorkspace())
+	if !errors.Is(err, ErrInvalid) || errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrInvalid, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "workspace.json") {
+		t.Errorf("expected original message to be kept, got %v", err)
+	}
+
+	os.WriteFile("workspace.json", []byte(`{"projects": {}}`), 0644)
+	if err := LoadWorkspace(NewWorkspace(), "missing.yaml"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for missing -f file, got %v", err)
+	}
+}
+
+func TestProjectTimeouts(t *testing.T) {
+	p := Project{Timeouts: map[string]string{"up": "5m", "build": "30m"}}
+	if d, err := p.Timeout("up"); err != nil || d != 5*time.Minute {
+		t.Errorf("expected 5m, got %v (%v)", d, err)
+	}
+	if d, err := p.Timeout("down"); err != nil || d != 0 {
+		t.Errorf("expected no timeout for down, got %v (%v)", d, err)
+	}
+	if err := p.ValidateTimeouts(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	for _, timeouts := range []map[string]string{{"up": "rápido"}, {"up": "-1s"}, {"deploy": "1m"}} {
+		if err := (Project{Timeouts: timeouts}).ValidateTimeouts(); err == nil {
+			t.Errorf("expected error for %v", timeouts)
+		}
+	}
+}
@@ -0,0 +1,402 @@
+// Package dcm permite usar o DCM a partir de outros programas Go: carregue um workspace, crie um
+// Manager e chame Up, Down, Status e as demais operações, que devolvem resultados tipados.
+//
+//	ws, err := dcm.LoadWorkspace("")
+//	if err != nil {
+//		return err
+//	}
+//	m := dcm.New(ws, dcm.WithOutput(io.Discard), dcm.WithConcurrency(4))
+//	result, err := m.Up(ctx, "dev", dcm.UpOptions{})
+//
+// O Manager não usa estado global: vários podem rodar no mesmo processo, com workspaces e opções
+// diferentes.
+package dcm
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/Disneyjr/dcm/internal/commands"
+	"github.com/Disneyjr/dcm/internal/workspace"
+)
+
+// Tipos compartilhados com a implementação, expostos com os mesmos campos.
+type (
+	Workspace     = workspace.Workspace
+	Project       = workspace.Project
+	Group         = workspace.Group
+	Readiness     = workspace.Readiness
+	Task          = workspace.Task
+	Event         = commands.Event
+	EventKind     = commands.EventKind
+	Reporter      = commands.Reporter
+	ReporterFunc  = commands.ReporterFunc
+	LiveReporter  = commands.LiveReporter
+	StatusResult  = commands.StatusResult
+	ProjectStatus = commands.ProjectStatus
+	ContainerInfo = commands.ContainerInfo
+	LogsOptions   = commands.LogsOptions
+	ComposeError  = commands.ComposeError
+	ExecOptions   = commands.ExecOptions
+	ExitError     = commands.ExitError
+	TasksResult   = commands.TasksResult
+	TaskInfo      = commands.TaskInfo
+)
+
+const (
+	OperationStarted    = commands.OperationStarted
+	OperationFinished   = commands.OperationFinished
+	WaveStarted         = commands.WaveStarted
+	StepStarted         = commands.StepStarted
+	StepFinished        = commands.StepFinished
+	StepFailed          = commands.StepFailed
+	StepSkipped         = commands.StepSkipped
+	ReadinessWaiting    = commands.ReadinessWaiting
+	ReadinessReady      = commands.ReadinessReady
+	RollbackStarted     = commands.RollbackStarted
+	RollbackStepStarted = commands.RollbackStepStarted
+	RollbackStepFailed  = commands.RollbackStepFailed
+)
+
+// Formatos aceitos por WithOutputFormat.
+const (
+	FormatText = commands.OutputText
+	FormatJSON = commands.OutputJSON
+	FormatYAML = commands.OutputYAML
+)
+
+// Classes de erro, identificáveis com errors.Is.
+var (
+	ErrComposeFailed     = commands.ErrComposeFailed  // O compose falhou em todos os serviços
+	ErrPartialFailure    = commands.ErrPartialFailure // Parte dos serviços falhou e parte deu certo
+	ErrWorkspaceNotFound = workspace.ErrNotFound
+	ErrInvalidConfig     = workspace.ErrInvalid
+)
+
+// NewConsoleReporter mostra uma linha por acontecimento, como o CLI faz por padrão. Com quiet só
+// mostra falhas, serviços ignorados e o que ficou sem iniciar.
+func NewConsoleReporter(out io.Writer, quiet bool) Reporter {
+	return commands.NewConsoleReporter(out, quiet)
+}
+
+// NewLiveReporter mostra uma linha com spinner para cada serviço em andamento, redesenhada no lugar.
+// out deve ser um terminal que aceita sequências ANSI. O reporter também é um io.Writer, e a saída
+// do Manager deve passar por ele, para não ser escrita por cima das linhas ao vivo:
+//
+//	live := dcm.NewLiveReporter(os.Stdout)
+//	m := dcm.New(ws, dcm.WithOutput(live), dcm.WithReporter(live))
+func NewLiveReporter(out io.Writer) *LiveReporter {
+	return commands.NewLiveReporter(out)
+}
+
+// NewJSONReporter escreve cada evento como uma linha JSON, para CI e outras ferramentas.
+func NewJSONReporter(out io.Writer) Reporter {
+	return commands.NewJSONReporter(out)
+}
+
+// NewSummaryReporter mostra, ao fim de cada operação com mais de um serviço, uma tabela com o
+// resultado e a duração de cada um. Costuma ser combinado a outro com MultiReporter.
+func NewSummaryReporter(out io.Writer) Reporter {
+	return commands.NewSummaryReporter(out)
+}
+
+// MultiReporter repassa cada evento a todos os reporters, na ordem; os nil são ignorados.
+func MultiReporter(reporters ...Reporter) Reporter {
+	return commands.MultiReporter(reporters...)
+}
+
+// LoadWorkspace carrega o workspace em path (arquivo ou diretório) ou, com path vazio, o mais
+// próximo do diretório atual. extraFiles são mesclados por cima, como o --file do CLI.
+func LoadWorkspace(path string, extraFiles ...string) (*Workspace, error) {
+	ws := workspace.NewWorkspace()
+	var err error
+	if path != "" {
+		err = workspace.LoadWorkspaceFile(ws, path, extraFiles...)
+	} else {
+		err = workspace.LoadWorkspace(ws, extraFiles...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ws, nil
+}
+
+// Manager executa as operações do DCM sobre um workspace. Os métodos podem ser chamados de várias
+// goroutines ao mesmo tempo.
+type Manager struct {
+	ws  *Workspace
+	cfg config
+
+	engineOnce sync.Once
+	engine     commands.Engine
+	engineErr  error
+}
+
+// New cria um Manager para o workspace. O engine do compose só é procurado na primeira operação
+// que o executa, então consultas como List e Validate funcionam sem ele instalado.
+func New(ws *Workspace, opts ...Option) *Manager {
+	m := &Manager{ws: ws, cfg: config{out: os.Stdout, errOut: os.Stderr, format: FormatText}}
+	return m.With(opts...)
+}
+
+// With devolve um Manager para o mesmo workspace com as opções deste e mais opts, por exemplo
+// para enviar a saída de uma parte do programa para outro lugar.
+func (m *Manager) With(opts ...Option) *Manager {
+	cfg := m.cfg
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &Manager{ws: m.ws, cfg: cfg}
+}
+
+func (m *Manager) Workspace() *Workspace {
+	return m.ws
+}
+
+// Groups lista os grupos do workspace em ordem alfabética.
+func (m *Manager) Groups() []string {
+	return commands.GroupNames(m.ws)
+}
+
+// Targets lista tudo o que é aceito como alvo: grupos, projetos e specs "projeto:serviço".
+func (m *Manager) Targets() []string {
+	return commands.TargetNames(m.ws)
+}
+
+// resolveEngine escolhe o engine uma única vez: WithEngine, DCM_ENGINE, o campo "engine" do
+// workspace ou o primeiro disponível no PATH. No dry-run nada é executado, então o padrão serve
+// se nenhum for encontrado.
+func (m *Manager) resolveEngine() (commands.Engine, error) {
+	m.engineOnce.Do(func() {
+		if m.cfg.engine != "" {
+			m.engine, m.engineErr = commands.LookupEngine(m.cfg.engine)
+			return
+		}
+		m.engine, m.engineErr = commands.ResolveEngine(m.ws)
+		if m.engineErr != nil && m.cfg.dryRun {
+			m.engine, m.engineErr = commands.DefaultEngine, nil
+		}
+	})
+	return m.engine, m.engineErr
+}
+
+// runner monta o Runner de uma chamada. Cada chamada tem o seu, para que os eventos de operações
+// simultâneas não se misturem nos resultados.
+func (m *Manager) runner(onEvent func(Event)) *commands.Runner {
+	r := commands.NewRunner(m.ws)
+	r.Out, r.ErrOut, r.DataOut = m.cfg.out, m.cfg.errOut, m.cfg.dataOut
+	r.DryRun = m.cfg.dryRun
+	r.Verbose = m.cfg.verbose
+	r.Quiet = m.cfg.quiet
+	r.FailFast = m.cfg.failFast
+	r.Jobs = m.cfg.jobs
+	r.OutputFormat = m.cfg.format
+	r.LogDir = m.cfg.logDir
+
+	reporter := m.cfg.reporter
+	if reporter == nil {
+		reporter = NewConsoleReporter(m.cfg.out, m.cfg.quiet)
+	}
+	var events []Reporter
+	for _, fn := range []func(Event){onEvent, m.cfg.onEvent} {
+		if fn != nil {
+			events = append(events, ReporterFunc(fn))
+		}
+	}
+	r.Reporter = commands.MultiReporter(append(events, reporter)...)
+	return r
+}
+
+// composeRunner é o runner das chamadas que executam o compose.
+func (m *Manager) composeRunner(onEvent func(Event)) (*commands.Runner, error) {
+	engine, err := m.resolveEngine()
+	if err != nil {
+		return nil, err
+	}
+	r := m.runner(onEvent)
+	r.Engine = engine
+	return r, nil
+}
+
+// operate executa uma operação que altera os serviços e monta o Result a partir dos seus eventos.
+func (m *Manager) operate(fn func(r *commands.Runner) error) (*Result, error) {
+	result := &Result{}
+	r, err := m.composeRunner(result.record)
+	if err != nil {
+		return result, err
+	}
+	return result, fn(r)
+}
+
+type UpOptions struct {
+	Build bool // Força o rebuild das imagens (up --build)
+}
+
+// Up inicia um grupo, projeto ou spec "projeto:serviço", respeitando as dependências e o onFailure
+// do grupo.
+func (m *Manager) Up(ctx context.Context, target string, opts UpOptions) (*Result, error) {
+	var extraArgs []string
+	if opts.Build {
+		extraArgs = append(extraArgs, "--build")
+	}
+	return m.operate(func(r *commands.Runner) error {
+		return r.UpGroup(ctx, target, extraArgs...)
+	})
+}
+
+type DownOptions struct {
+	RemoveVolumes bool
+}
+
+// Down para o alvo na ordem inversa da inicialização; target vazio para o workspace inteiro.
+func (m *Manager) Down(ctx context.Context, target string, opts DownOptions) (*Result, error) {
+	return m.operate(func(r *commands.Runner) error {
+		if target == "" {
+			return r.DownAll(ctx, opts.RemoveVolumes)
+		}
+		return r.DownGroup(ctx, target, opts.RemoveVolumes)
+	})
+}
+
+// DownLast para exatamente o que o último "up" registrado no workspace iniciou.
+func (m *Manager) DownLast(ctx context.Context, opts DownOptions) (*Result, error) {
+	return m.operate(func(r *commands.Runner) error {
+		return r.DownLast(ctx, opts.RemoveVolumes)
+	})
+}
+
+// Restart reinicia o alvo; target vazio reinicia o workspace inteiro.
+func (m *Manager) Restart(ctx context.Context, target string) (*Result, error) {
+	return m.operate(func(r *commands.Runner) error {
+		return r.RestartGroup(ctx, target)
+	})
+}
+
+// Pull baixa as imagens do alvo; target vazio vale para o workspace inteiro.
+func (m *Manager) Pull(ctx context.Context, target string) (*Result, error) {
+	return m.operate(func(r *commands.Runner) error {
+		return r.PullGroup(ctx, target)
+	})
+}
+
+type BuildOptions struct {
+	NoCache bool
+	Pull    bool // Sempre tenta baixar versões novas das imagens base
+}
+
+// Build constrói as imagens do alvo; target vazio vale para o workspace inteiro.
+func (m *Manager) Build(ctx context.Context, target string, opts BuildOptions) (*Result, error) {
+	var extraArgs []string
+	if opts.NoCache {
+		extraArgs = append(extraArgs, "--no-cache")
+	}
+	if opts.Pull {
+		extraArgs = append(extraArgs, "--pull")
+	}
+	return m.operate(func(r *commands.Runner) error {
+		return r.BuildGroup(ctx, target, extraArgs...)
+	})
+}
+
+// Status consulta o estado dos containers do alvo (vazio = workspace inteiro) sem imprimir nada.
+func (m *Manager) Status(ctx context.Context, target string) (StatusResult, error) {
+	r, err := m.composeRunner(nil)
+	if err != nil {
+		return StatusResult{}, err
+	}
+	return r.CollectStatus(ctx, target)
+}
+
+// Logs escreve em out os logs do alvo, com um prefixo por projeto, até o fim dos streams ou o
+// cancelamento de ctx (com Follow, só o cancelamento encerra).
+func (m *Manager) Logs(ctx context.Context, target string, opts LogsOptions, out io.Writer) error {
+	r, err := m.composeRunner(nil)
+	if err != nil {
+		return err
+	}
+	return r.StreamLogs(ctx, target, opts, out)
+}
+
+// Exec executa command em um container que já está rodando ("compose exec"), ligado aos streams de
+// opts. spec é "projeto:serviço"; o serviço pode ser omitido quando o projeto tem um só. Um código
+// de saída diferente de zero volta como *ExitError.
+func (m *Manager) Exec(ctx context.Context, spec string, command []string, opts ExecOptions) error {
+	r, err := m.composeRunner(nil)
+	if err != nil {
+		return err
+	}
+	return r.Exec(ctx, spec, command, opts)
+}
+
+// Run é como Exec, mas usa um container novo, removido ao terminar ("compose run --rm").
+func (m *Manager) Run(ctx context.Context, spec string, command []string, opts ExecOptions) error {
+	r, err := m.composeRunner(nil)
+	if err != nil {
+		return err
+	}
+	return r.RunOnce(ctx, spec, command, opts)
+}
+
+// RunTask executa uma tarefa do projeto (campo "tasks"), com extraArgs no final do comando.
+func (m *Manager) RunTask(ctx context.Context, project, task string, extraArgs []string, opts ExecOptions) error {
+	r, err := m.composeRunner(nil)
+	if err != nil {
+		return err
+	}
+	return r.RunTask(ctx, project, task, extraArgs, opts)
+}
+
+// Tasks lista as tarefas de um projeto ou, com project vazio, de todo o workspace.
+func (m *Manager) Tasks(project string) (TasksResult, error) {
+	return commands.BuildTasksResult(m.ws, project)
+}
+
+// Validate confere o workspace, escreve cada problema na saída e devolve um erro ErrInvalidConfig
+// se houver algum.
+func (m *Manager) Validate() error {
+	return m.runner(nil).ValidateWorkspace()
+}
+
+// Os métodos Print* escrevem relatórios na saída do Manager, no formato de WithOutputFormat.
+
+// PrintStatus mostra o estado dos containers do alvo: "compose ps" de cada projeto no formato
+// texto ou um StatusResult nos formatos estruturados.
+func (m *Manager) PrintStatus(ctx context.Context, target string) error {
+	r, err := m.composeRunner(nil)
+	if err != nil {
+		return err
+	}
+	return r.StatusGroup(ctx, target)
+}
+
+// PrintList mostra os projetos e grupos do workspace.
+func (m *Manager) PrintList() error {
+	return m.runner(nil).ListAll()
+}
+
+// PrintInspect mostra a configuração resolvida de um grupo e a ordem de inicialização.
+func (m *Manager) PrintInspect(group string) error {
+	return m.runner(nil).InspectGroup(group)
+}
+
+// PrintConfig mostra o workspace mesclado e interpolado (JSON no formato texto).
+func (m *Manager) PrintConfig() error {
+	return m.runner(nil).PrintConfig()
+}
+
+// PrintConfigSources mostra de qual arquivo veio cada valor do workspace.
+func (m *Manager) PrintConfigSources() error {
+	return m.runner(nil).PrintConfigSources()
+}
+
+// PrintTasks mostra as tarefas de um projeto ou, com project vazio, de todo o workspace.
+func (m *Manager) PrintTasks(project string) error {
+	return m.runner(nil).ListTasks(project)
+}
+
+// PrintHistory mostra as últimas execuções registradas (limit <= 0 mostra todas).
+func (m *Manager) PrintHistory(limit int) error {
+	return m.runner(nil).History(limit)
+}
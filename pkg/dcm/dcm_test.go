@@ -0,0 +1,115 @@
+package dcm
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func testWorkspace(t *testing.T) *Workspace {
+	parallelFalse := false
+	return &Workspace{
+		BaseDir: t.TempDir(),
+		Projects: map[string]Project{
+			"db":  {Path: "./db"},
+			"api": {Path: "./api", DependsOn: []string{"db"}},
+		},
+		Groups: map[string]Group{
+			"dev": {Services: []string{"api"}, Parallel: &parallelFalse},
+		},
+	}
+}
+
+func TestManagerUp(t *testing.T) {
+	var out strings.Builder
+	var mu sync.Mutex
+	var kinds []string
+	m := New(testWorkspace(t), WithDryRun(true), WithOutput(&out), WithEvents(func(e Event) {
+		mu.Lock()
+		kinds = append(kinds, string(e.Kind))
+		mu.Unlock()
+	}))
+
+	result, err := m.Up(context.Background(), "dev", UpOptions{Build: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Operation != "up" || result.Target != "dev" || strings.Join(result.Succeeded(), ",") != "db,api" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if !strings.Contains(out.String(), "cd ./api && docker compose up -d --build") {
+		t.Errorf("expected the output to go to the writer:\n%s", out.String())
+	}
+	if len(kinds) != 6 || kinds[0] != string(OperationStarted) || kinds[5] != string(OperationFinished) {
+		t.Errorf("unexpected events: %v", kinds)
+	}
+}
+
+func TestManagerResultOnFailure(t *testing.T) {
+	ws := testWorkspace(t)
+	db := ws.Projects["db"]
+	db.Readiness = &Readiness{Type: "invalid"}
+	ws.Projects["db"] = db
+
+	m := New(ws, WithDryRun(true), WithOutput(&strings.Builder{}))
+	result, err := m.Up(context.Background(), "dev", UpOptions{})
+	if !errors.Is(err, ErrComposeFailed) {
+		t.Errorf("expected compose failure, got %v", err)
+	}
+	if strings.Join(result.Failed(), ",") != "db" || strings.Join(result.Skipped(), ",") != "api" {
+		t.Errorf("unexpected result: %+v", result.Steps)
+	}
+	if result.Steps[0].Err == nil {
+		t.Error("expected the failed step to carry its error")
+	}
+}
+
+func TestManagerUnknownEngine(t *testing.T) {
+	m := New(testWorkspace(t), WithEngine("nada"), WithOutput(&strings.Builder{}))
+
+	result, err := m.Down(context.Background(), "", DownOptions{})
+	if err == nil || !strings.Contains(err.Error(), "nada") {
+		t.Errorf("expected unknown engine error, got %v", err)
+	}
+	if len(result.Steps) != 0 {
+		t.Errorf("expected nothing to run, got %+v", result.Steps)
+	}
+
+	// Consultas que não executam o compose não precisam do engine
+	if err := m.PrintList(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestManagerWith(t *testing.T) {
+	var first, second strings.Builder
+	m := New(testWorkspace(t), WithDryRun(true), WithOutput(&first))
+	quiet := m.With(WithOutput(&second), WithQuiet(true))
+
+	if _, err := quiet.Restart(context.Background(), "db"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.Len() != 0 {
+		t.Errorf("expected the original manager's output to be untouched:\n%s", first.String())
+	}
+	if !strings.Contains(second.String(), "docker compose restart") || strings.Contains(second.String(), "✨") {
+		t.Errorf("expected only the dry-run commands:\n%s", second.String())
+	}
+}
+
+func TestManagerReporter(t *testing.T) {
+	var out, progress strings.Builder
+	m := New(testWorkspace(t), WithDryRun(true), WithOutput(&out), WithReporter(NewJSONReporter(&progress)))
+
+	if _, err := m.Up(context.Background(), "dev", UpOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(progress.String()), "\n"); len(lines) != 6 {
+		t.Errorf("expected one JSON line per event, got:\n%s", progress.String())
+	}
+	if strings.Contains(out.String(), "Iniciando") || !strings.Contains(out.String(), "[DRY-RUN]") {
+		t.Errorf("expected only the commands in the output:\n%s", out.String())
+	}
+}
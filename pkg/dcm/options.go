@@ -0,0 +1,114 @@
+package dcm
+
+import (
+	"io"
+	"sync"
+)
+
+type config struct {
+	engine   string
+	out      io.Writer
+	errOut   io.Writer
+	dataOut  io.Writer
+	dryRun   bool
+	verbose  bool
+	quiet    bool
+	failFast bool
+	jobs     int
+	format   string
+	logDir   string
+	onEvent  func(Event)
+	reporter Reporter
+}
+
+// Option configura um Manager em New.
+type Option func(*config)
+
+// WithEngine fixa o engine do compose: "docker", "docker-compose", "podman-compose" ou "nerdctl".
+// Sem ela, vale DCM_ENGINE, o campo "engine" do workspace ou o primeiro disponível no PATH.
+func WithEngine(name string) Option {
+	return func(c *config) { c.engine = name }
+}
+
+// WithOutput envia o progresso, os relatórios e a saída do compose para w (padrão: stdout e
+// stderr). As escritas são serializadas, então w não precisa ser seguro para uso concorrente.
+// Use io.Discard para silenciar tudo.
+func WithOutput(w io.Writer) Option {
+	return func(c *config) {
+		locked := &lockedWriter{w: w}
+		c.out, c.errOut = locked, locked
+	}
+}
+
+// WithErrorOutput envia o stderr do compose para w. Use depois de WithOutput, que troca as duas
+// saídas.
+func WithErrorOutput(w io.Writer) Option {
+	return func(c *config) { c.errOut = &lockedWriter{w: w} }
+}
+
+// WithDataOutput envia os documentos dos relatórios Print* nos formatos FormatJSON e FormatYAML
+// para w, em vez da saída de WithOutput. O CLI usa com --progress json, que leva o resto ao stderr.
+func WithDataOutput(w io.Writer) Option {
+	return func(c *config) { c.dataOut = w }
+}
+
+// WithDryRun faz as operações apenas mostrarem os comandos, sem executá-los nem gravar o histórico.
+func WithDryRun(enabled bool) Option {
+	return func(c *config) { c.dryRun = enabled }
+}
+
+// WithConcurrency limita quantos serviços são executados ao mesmo tempo nos grupos paralelos.
+// Tem precedência sobre o maxParallel dos grupos; n <= 0 mantém o do grupo.
+func WithConcurrency(n int) Option {
+	return func(c *config) { c.jobs = n }
+}
+
+// WithVerbose mostra cada comando executado e toda a saída do compose.
+func WithVerbose(enabled bool) Option {
+	return func(c *config) { c.verbose = enabled }
+}
+
+// WithQuiet suprime as mensagens de progresso; erros e relatórios continuam sendo escritos.
+func WithQuiet(enabled bool) Option {
+	return func(c *config) { c.quiet = enabled }
+}
+
+// WithFailFast interrompe o Up na primeira falha, mesmo em grupos com onFailure "continue".
+func WithFailFast(enabled bool) Option {
+	return func(c *config) { c.failFast = enabled }
+}
+
+// WithOutputFormat escolhe o formato dos relatórios Print*: FormatText, FormatJSON ou FormatYAML.
+func WithOutputFormat(format string) Option {
+	return func(c *config) { c.format = format }
+}
+
+// WithLogDir grava a saída completa de cada comando do compose em dir/<projeto>.log.
+func WithLogDir(dir string) Option {
+	return func(c *config) { c.logDir = dir }
+}
+
+// WithEvents chama fn a cada evento das operações (início e fim de cada operação e de cada spec).
+// fn pode ser chamada de várias goroutines ao mesmo tempo e não deve bloquear.
+func WithEvents(fn func(Event)) Option {
+	return func(c *config) { c.onEvent = fn }
+}
+
+// WithReporter troca a forma de mostrar o progresso das operações, que por padrão é um
+// ConsoleReporter na saída do Manager. nil volta ao padrão. O mesmo reporter recebe as operações de
+// todas as chamadas, então os deste pacote devem acompanhar uma chamada por vez.
+func WithReporter(r Reporter) Option {
+	return func(c *config) { c.reporter = r }
+}
+
+// lockedWriter serializa as escritas que chegam das execuções em paralelo.
+type lockedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
+}
@@ -0,0 +1,71 @@
+package dcm
+
+import (
+	"sync"
+	"time"
+)
+
+type StepStatus string
+
+const (
+	StatusOK      StepStatus = "ok"
+	StatusFailed  StepStatus = "failed"
+	StatusSkipped StepStatus = "skipped" // Dependência falhou ou a operação foi interrompida antes
+)
+
+// Step é o resultado de uma operação em um spec.
+type Step struct {
+	Spec     string
+	Status   StepStatus
+	Duration time.Duration
+	Err      error
+}
+
+// Result resume uma operação. É devolvido mesmo quando a operação falha, com o que chegou a
+// acontecer; antes de a operação começar (alvo inexistente, engine ausente) ele fica vazio.
+type Result struct {
+	Operation string
+	Target    string
+	Duration  time.Duration
+	Steps     []Step // Na ordem em que terminaram
+
+	mu sync.Mutex
+}
+
+// record monta o Result a partir dos eventos da operação.
+func (r *Result) record(e Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch e.Kind {
+	case OperationStarted:
+		r.Operation, r.Target = e.Operation, e.Target
+	case OperationFinished:
+		r.Duration = e.Duration
+	case StepFinished:
+		r.Steps = append(r.Steps, Step{Spec: e.Spec, Status: StatusOK, Duration: e.Duration})
+	case StepFailed:
+		r.Steps = append(r.Steps, Step{Spec: e.Spec, Status: StatusFailed, Duration: e.Duration, Err: e.Err})
+	case StepSkipped:
+		r.Steps = append(r.Steps, Step{Spec: e.Spec, Status: StatusSkipped, Err: e.Err})
+	}
+}
+
+func (r *Result) specs(status StepStatus) []string {
+	var specs []string
+	for _, step := range r.Steps {
+		if step.Status == status {
+			specs = append(specs, step.Spec)
+		}
+	}
+	return specs
+}
+
+// Succeeded lista os specs em que a operação deu certo.
+func (r *Result) Succeeded() []string { return r.specs(StatusOK) }
+
+// Failed lista os specs em que a operação falhou.
+func (r *Result) Failed() []string { return r.specs(StatusFailed) }
+
+// Skipped lista os specs que não foram executados.
+func (r *Result) Skipped() []string { return r.specs(StatusSkipped) }
@@ -0,0 +1,8 @@
+//go:build !windows
+
+package messages
+
+// ownsConsole só é relevante no Windows; nos demais sistemas o terminal sempre sobrevive ao processo.
+func ownsConsole() bool {
+	return false
+}
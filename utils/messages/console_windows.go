@@ -0,0 +1,19 @@
+//go:build windows
+
+package messages
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var procGetConsoleProcessList = windows.NewLazySystemDLL("kernel32.dll").NewProc("GetConsoleProcessList")
+
+// ownsConsole informa se o console foi criado para este processo, como acontece ao abrir o .exe
+// com duplo clique: nesse caso o processo é o único anexado a ele e a janela fecha ao sair.
+func ownsConsole() bool {
+	var pids [2]uint32
+	n, _, _ := procGetConsoleProcessList.Call(uintptr(unsafe.Pointer(&pids[0])), uintptr(len(pids)))
+	return n == 1
+}
@@ -0,0 +1,161 @@
+// Package terminal decide o que a saída do dcm pode usar: cores ANSI (conforme o terminal,
+// NO_COLOR, FORCE_COLOR e --color) e emojis (removidos com --plain).
+package terminal
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Disneyjr/dcm/internal/i18n"
+	"golang.org/x/term"
+)
+
+// Modos da flag --color.
+const (
+	ColorAuto   = "auto"   // Cores apenas em terminais, respeitando NO_COLOR e FORCE_COLOR
+	ColorAlways = "always" // Cores mesmo em pipes e arquivos
+	ColorNever  = "never"
+)
+
+var ColorModes = []string{ColorAuto, ColorAlways, ColorNever}
+
+// Até o Setup a saída é a mais conservadora: sem cores, com emojis.
+var (
+	color       = false
+	plain       = false
+	interactive = false
+)
+
+func ParseColorMode(mode string) (string, error) {
+	switch mode {
+	case "":
+		return ColorAuto, nil
+	case ColorAuto, ColorAlways, ColorNever:
+		return mode, nil
+	}
+	return "", i18n.Errorf("terminal.invalid_color", mode, strings.Join(ColorModes, ", "))
+}
+
+// Setup decide se a saída usa cores e emojis. No Windows também liga o processamento de sequências
+// ANSI do console, sem o qual as cores apareceriam como texto.
+func Setup(mode string, plainOutput bool) error {
+	mode, err := ParseColorMode(mode)
+	if err != nil {
+		return err
+	}
+
+	plain = plainOutput
+	// As sequências ANSI também movem o cursor no "dcm ui", então o console é preparado mesmo sem cores
+	vt := enableVirtualTerminal(os.Stdout)
+	enableVirtualTerminal(os.Stderr)
+	// Um console antigo sem suporte a ANSI só recebe cores se elas forem pedidas explicitamente
+	color = colorEnabled(mode) && (vt || colorForced(mode))
+	interactive = vt && IsTerminal(os.Stdout) && os.Getenv("TERM") != "dumb"
+	return nil
+}
+
+// colorEnabled segue a precedência: --color, NO_COLOR, FORCE_COLOR e, por fim, se a saída é um
+// terminal capaz de exibir cores.
+func colorEnabled(mode string) bool {
+	switch mode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	if value, ok := os.LookupEnv("FORCE_COLOR"); ok {
+		return value != "0" && value != "false"
+	}
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return IsTerminal(os.Stdout)
+}
+
+// colorForced informa se as cores foram pedidas explicitamente, com --color always ou FORCE_COLOR
+// (e sem um NO_COLOR, que vale mais). Nesse caso elas saem mesmo quando o console não interpreta
+// ANSI, por exemplo no Windows com a saída redirecionada.
+func colorForced(mode string) bool {
+	switch mode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	value, ok := os.LookupEnv("FORCE_COLOR")
+	return ok && value != "0" && value != "false"
+}
+
+// Color informa se a saída pode usar cores ANSI.
+func Color() bool {
+	return color
+}
+
+// Plain informa se a saída deve ser apenas texto, sem emojis (--plain).
+func Plain() bool {
+	return plain
+}
+
+// Interactive informa se a saída padrão é um terminal que aceita mover o cursor com sequências ANSI,
+// como exige o progresso ao vivo. Independe das cores: NO_COLOR não desliga o progresso ao vivo.
+func Interactive() bool {
+	return interactive
+}
+
+// Width devolve a largura do terminal para o qual w escreve, ou 0 se w não é um terminal.
+func Width(w io.Writer) int {
+	f, ok := w.(*os.File)
+	if !ok {
+		return 0
+	}
+	width, _, err := term.GetSize(int(f.Fd()))
+	if err != nil {
+		return 0
+	}
+	return width
+}
+
+func IsTerminal(f *os.File) bool {
+	return term.IsTerminal(int(f.Fd()))
+}
+
+// isEmoji cobre os blocos de pictogramas usados nas mensagens, os símbolos que os terminais exibem
+// como emoji e os modificadores de apresentação (seletor de variação e ZWJ).
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF, // Pictogramas, emoticons, transporte, símbolos suplementares
+		r >= 0x2600 && r <= 0x27BF, // Símbolos diversos e dingbats (✅, ✨, ❌, ⚠)
+		r >= 0x2300 && r <= 0x23FF, // Técnicos diversos (⏳, ⏹)
+		r >= 0x2B00 && r <= 0x2BFF, // Setas e símbolos diversos (⭐)
+		r == 0x21A9 || r == 0x21AA, // ↩ e ↪
+		r == 0xFE0F || r == 0x200D:
+		return true
+	}
+	return false
+}
+
+// StripEmoji remove os emojis de s, junto com o espaço que os separa do texto seguinte.
+func StripEmoji(s string) string {
+	var b strings.Builder
+	skipSpace := false
+	for _, r := range s {
+		if isEmoji(r) {
+			skipSpace = true
+			continue
+		}
+		if skipSpace && r == ' ' {
+			skipSpace = false
+			continue
+		}
+		skipSpace = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,10 @@
+//go:build !windows
+
+package terminal
+
+import "os"
+
+// enableVirtualTerminal só é necessário no Windows; os demais terminais interpretam ANSI.
+func enableVirtualTerminal(f *os.File) bool {
+	return true
+}
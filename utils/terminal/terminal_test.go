@@ -0,0 +1,86 @@
+package terminal
+
+import (
+	"os"
+	"testing"
+)
+
+func TestParseColorMode(t *testing.T) {
+	for _, mode := range []string{"auto", "always", "never"} {
+		if got, err := ParseColorMode(mode); err != nil || got != mode {
+			t.Errorf("ParseColorMode(%q) = %q, %v", mode, got, err)
+		}
+	}
+	if got, _ := ParseColorMode(""); got != ColorAuto {
+		t.Errorf("vazio deveria ser auto, veio %q", got)
+	}
+	if _, err := ParseColorMode("sometimes"); err == nil {
+		t.Error("modo inválido deveria falhar")
+	}
+}
+
+func TestColorEnabled(t *testing.T) {
+	tests := []struct {
+		name                    string
+		mode, noColor, force, t string
+		want                    bool
+		forced                  bool // Vale mesmo em um console sem ANSI
+	}{
+		// A saída dos testes não é um terminal
+		{name: "auto sem terminal", mode: ColorAuto, want: false},
+		{name: "always", mode: ColorAlways, noColor: "1", want: true, forced: true},
+		{name: "never", mode: ColorNever, force: "1", want: false},
+		{name: "FORCE_COLOR", mode: ColorAuto, force: "1", want: true, forced: true},
+		{name: "FORCE_COLOR=0", mode: ColorAuto, force: "0", want: false},
+		{name: "NO_COLOR vence FORCE_COLOR", mode: ColorAuto, noColor: "1", force: "1", want: false},
+		{name: "TERM=dumb", mode: ColorAuto, t: "dumb", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tt.noColor)
+			t.Setenv("TERM", tt.t)
+			// t.Setenv restaura o valor original no fim; LookupEnv distingue ausente de vazio
+			t.Setenv("FORCE_COLOR", tt.force)
+			if tt.force == "" {
+				os.Unsetenv("FORCE_COLOR")
+			}
+			if got := colorEnabled(tt.mode); got != tt.want {
+				t.Errorf("colorEnabled = %v, esperado %v", got, tt.want)
+			}
+			if got := colorForced(tt.mode); got != tt.forced {
+				t.Errorf("colorForced = %v, esperado %v", got, tt.forced)
+			}
+		})
+	}
+}
+
+func TestSetup(t *testing.T) {
+	t.Cleanup(func() { color, plain = false, false })
+
+	if err := Setup(ColorAlways, true); err != nil {
+		t.Fatal(err)
+	}
+	if !Color() || !Plain() {
+		t.Errorf("Color=%v Plain=%v, esperado true e true", Color(), Plain())
+	}
+	if err := Setup("rainbow", false); err == nil {
+		t.Error("modo inválido deveria falhar")
+	}
+}
+
+func TestStripEmoji(t *testing.T) {
+	tests := map[string]string{
+		"🚀 Iniciando api":      "Iniciando api",
+		"⚠️ aviso":             "aviso",
+		"✅":                    "",
+		"↩️ Revertendo":        "Revertendo",
+		"sem emoji: │ a → b ●": "sem emoji: │ a → b ●",
+		"✗ erro: falhou":       "erro: falhou",
+		"🗑️  Removendo":        " Removendo",
+	}
+	for in, want := range tests {
+		if got := StripEmoji(in); got != want {
+			t.Errorf("StripEmoji(%q) = %q, esperado %q", in, got, want)
+		}
+	}
+}
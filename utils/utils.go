@@ -5,21 +5,41 @@ import (
 	"os"
 	"os/exec"
 	"runtime"
+
+	"github.com/Disneyjr/dcm/utils/terminal"
 )
 
+var colors = map[string]string{
+	"red":     "\033[31m",
+	"green":   "\033[32m",
+	"blue":    "\033[34m",
+	"cyan":    "\033[36m",
+	"yellow":  "\033[33m",
+	"magenta": "\033[35m",
+}
+
+const colorReset = "\033[0m"
+
+// Colorize pinta text quando a saída aceita cores (veja o pacote terminal). No modo --plain os
+// emojis de text são removidos.
 func Colorize(color, text string) string {
-	if runtime.GOOS == "windows" {
+	if terminal.Plain() {
+		text = terminal.StripEmoji(text)
+	}
+	code, ok := colors[color]
+	if !ok || text == "" || !terminal.Color() {
 		return text
 	}
-	colors := map[string]string{
-		"red":    "\033[31m",
-		"green":  "\033[32m",
-		"blue":   "\033[34m",
-		"cyan":   "\033[36m",
-		"yellow": "\033[33m",
-		"reset":  "\033[0m",
+	return code + text + colorReset
+}
+
+// Icon devolve o emoji que abre uma mensagem, colorido e seguido de um espaço. No modo --plain
+// devolve "", para que a mensagem comece direto no texto.
+func Icon(color, icon string) string {
+	if terminal.Plain() {
+		return ""
 	}
-	return colors[color] + text + colors["reset"]
+	return Colorize(color, icon) + " "
 }
 
 func GetSystemInfo() string {